@@ -0,0 +1,141 @@
+package avro
+
+import (
+	"bytes"
+	"crypto/md5" //nolint:gosec // MD5 fingerprints are part of the Avro specification
+	"crypto/sha256"
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// CanonicalForm returns the Parsing Canonical Form of the schema, in which schemas
+// that are equivalent for reading data have the same representation.
+// Doc strings, aliases, defaults and logical types are removed, names are replaced by
+// full names, and attributes are written in a fixed order without whitespace.
+// See: https://avro.apache.org/docs/current/specification/#parsing-canonical-form-for-schemas
+func CanonicalForm(schema Schema) []byte {
+	var b bytes.Buffer
+	writeCanonical(&b, schema, "")
+	return b.Bytes()
+}
+
+// Fingerprint64 returns the CRC-64-AVRO (Rabin) fingerprint of the Parsing Canonical Form of the schema.
+func Fingerprint64(schema Schema) uint64 {
+	return crc64Avro(CanonicalForm(schema))
+}
+
+// FingerprintMD5 returns the MD5 fingerprint of the Parsing Canonical Form of the schema.
+func FingerprintMD5(schema Schema) [md5.Size]byte {
+	return md5.Sum(CanonicalForm(schema)) //nolint:gosec // MD5 fingerprints are part of the Avro specification
+}
+
+// FingerprintSHA256 returns the SHA-256 fingerprint of the Parsing Canonical Form of the schema.
+func FingerprintSHA256(schema Schema) [sha256.Size]byte {
+	return sha256.Sum256(CanonicalForm(schema))
+}
+
+// writeCanonical writes the canonical form of schema, where namespace is the enclosing namespace.
+func writeCanonical(b *bytes.Buffer, schema Schema, namespace string) {
+	switch s := schema.(type) {
+	case Reference:
+		name := string(s)
+		if !strings.Contains(name, ".") && namespace != "" {
+			name = namespace + "." + name
+		}
+		writeString(b, name)
+	case Primitive:
+		writeString(b, string(s.Type))
+	case Union:
+		b.WriteByte('[')
+		for i, branch := range s {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			writeCanonical(b, branch, namespace)
+		}
+		b.WriteByte(']')
+	case Array:
+		b.WriteString(`{"type":"array","items":`)
+		writeCanonical(b, s.Items, namespace)
+		b.WriteByte('}')
+	case Map:
+		b.WriteString(`{"type":"map","values":`)
+		writeCanonical(b, s.Values, namespace)
+		b.WriteByte('}')
+	case Record:
+		full := fullName(s.Namespace, s.Name, namespace)
+		b.WriteString(`{"name":`)
+		writeString(b, full)
+		b.WriteString(`,"type":"record","fields":[`)
+		for i, field := range s.Fields {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(`{"name":`)
+			writeString(b, field.Name)
+			b.WriteString(`,"type":`)
+			writeCanonical(b, field.Type, namespaceOf(full))
+			b.WriteByte('}')
+		}
+		b.WriteString(`]}`)
+	case Enum:
+		b.WriteString(`{"name":`)
+		writeString(b, fullName(s.Namespace, s.Name, namespace))
+		b.WriteString(`,"type":"enum","symbols":[`)
+		for i, symbol := range s.Symbols {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			writeString(b, symbol)
+		}
+		b.WriteString(`]}`)
+	case Fixed:
+		b.WriteString(`{"name":`)
+		writeString(b, fullName(s.Namespace, s.Name, namespace))
+		b.WriteString(`,"type":"fixed","size":`)
+		b.WriteString(strconv.Itoa(s.Size))
+		b.WriteByte('}')
+	}
+}
+
+// writeString writes a JSON string literal, without escaping non-ASCII or HTML characters.
+func writeString(b *bytes.Buffer, s string) {
+	enc := json.NewEncoder(b)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	// Encode terminates the value with a newline
+	b.Truncate(b.Len() - 1)
+}
+
+// namespaceOf returns the namespace of a full name.
+func namespaceOf(full string) string {
+	if i := strings.LastIndex(full, "."); i >= 0 {
+		return full[:i]
+	}
+	return ""
+}
+
+// crc64AvroEmpty is the fingerprint of an empty input, and the
+// irreducible polynomial of CRC-64-AVRO.
+const crc64AvroEmpty = 0xc15d213aa4d7a795
+
+var crc64AvroTable = func() [256]uint64 {
+	var table [256]uint64
+	for i := range table {
+		fp := uint64(i)
+		for j := 0; j < 8; j++ {
+			fp = (fp >> 1) ^ (crc64AvroEmpty & -(fp & 1))
+		}
+		table[i] = fp
+	}
+	return table
+}()
+
+func crc64Avro(data []byte) uint64 {
+	fp := uint64(crc64AvroEmpty)
+	for _, b := range data {
+		fp = (fp >> 8) ^ crc64AvroTable[(byte(fp)^b)&0xff]
+	}
+	return fp
+}
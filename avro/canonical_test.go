@@ -0,0 +1,127 @@
+package avro
+
+import (
+	"crypto/md5" //nolint:gosec // MD5 fingerprints are part of the Avro specification
+	"crypto/sha256"
+	"encoding/json"
+	"testing"
+
+	"github.com/linkedin/goavro/v2"
+	"gotest.tools/v3/assert"
+)
+
+func TestCanonicalForm(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		name     string
+		schema   Schema
+		expected string
+		// goavro deviates from the specification for logical types and nested named types
+		skipGoavro bool
+	}{
+		{
+			name:     "primitive",
+			schema:   Long(),
+			expected: `"long"`,
+		},
+		{
+			name:       "logical type",
+			schema:     TimestampMicros(),
+			expected:   `"long"`,
+			skipGoavro: true,
+		},
+		{
+			name: "record",
+			schema: Nullable(Record{
+				Type:      RecordType,
+				Namespace: "google.example.library.v1",
+				Doc:       "A book.",
+				Name:      "Book",
+				Aliases:   []string{"Volume"},
+				Fields: []Field{
+					{Name: "name", Doc: "The name.", Type: Nullable(String()), Default: NullDefault},
+					{
+						Name: "genre",
+						Type: Enum{Type: EnumType, Name: "Genre", Symbols: []string{"FICTION", "POETRY"}, Default: "FICTION"},
+					},
+					{Name: "tags", Type: Array{Type: ArrayType, Items: String()}},
+					{Name: "props", Type: Map{Type: MapType, Values: Reference("Genre")}},
+					{Name: "id", Type: Fixed{Type: FixedType, Name: "id", Namespace: "ids", Size: 16}},
+					{Name: "next", Type: Nullable(Reference("google.example.library.v1.Book"))},
+				},
+			}),
+			expected: `["null",{"name":"google.example.library.v1.Book","type":"record","fields":[` +
+				`{"name":"name","type":["null","string"]},` +
+				`{"name":"genre","type":{"name":"google.example.library.v1.Genre","type":"enum","symbols":["FICTION","POETRY"]}},` +
+				`{"name":"tags","type":{"type":"array","items":"string"}},` +
+				`{"name":"props","type":{"type":"map","values":"google.example.library.v1.Genre"}},` +
+				`{"name":"id","type":{"name":"ids.id","type":"fixed","size":16}},` +
+				`{"name":"next","type":["null","google.example.library.v1.Book"]}]}]`,
+			skipGoavro: true,
+		},
+		{
+			name: "nested namespaces",
+			schema: Record{
+				Type:      RecordType,
+				Namespace: "einride.avro.example.v1",
+				Name:      "ExampleMap",
+				Fields: []Field{
+					{
+						Name: "entries",
+						Type: Array{Type: ArrayType, Items: Record{
+							Type:      RecordType,
+							Namespace: "einride.avro.example.v1.ExampleMap",
+							Name:      "Entry",
+							Fields:    []Field{{Name: "key", Type: String()}, {Name: "value", Type: Double()}},
+						}},
+					},
+				},
+			},
+			expected: `{"name":"einride.avro.example.v1.ExampleMap","type":"record","fields":[` +
+				`{"name":"entries","type":{"type":"array","items":{"name":"einride.avro.example.v1.ExampleMap.Entry",` +
+				`"type":"record","fields":[{"name":"key","type":"string"},{"name":"value","type":"double"}]}}}]}`,
+			skipGoavro: true,
+		},
+		{
+			name: "flat record",
+			schema: Record{
+				Type:      RecordType,
+				Namespace: "google.example.library.v1",
+				Name:      "Book",
+				Fields: []Field{
+					{Name: "name", Type: Nullable(String()), Default: NullDefault},
+					{Name: "read", Type: Boolean()},
+				},
+			},
+			expected: `{"name":"google.example.library.v1.Book","type":"record","fields":[` +
+				`{"name":"name","type":["null","string"]},{"name":"read","type":"boolean"}]}`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := CanonicalForm(tt.schema)
+			assert.Equal(t, tt.expected, string(got))
+			assert.Equal(t, md5.Sum(got), FingerprintMD5(tt.schema)) //nolint:gosec // see import
+			assert.Equal(t, sha256.Sum256(got), FingerprintSHA256(tt.schema))
+			if tt.skipGoavro {
+				return
+			}
+			// assert that the canonical form and fingerprint match goavro
+			schemaBytes, err := json.Marshal(tt.schema)
+			assert.NilError(t, err)
+			codec, err := goavro.NewCodec(string(schemaBytes))
+			assert.NilError(t, err)
+			assert.Equal(t, codec.CanonicalSchema(), string(got))
+			assert.Equal(t, codec.Rabin, Fingerprint64(tt.schema))
+		})
+	}
+}
+
+func TestFingerprint64(t *testing.T) {
+	t.Parallel()
+	// fingerprint of the empty input
+	assert.Equal(t, uint64(0xc15d213aa4d7a795), crc64Avro(nil))
+	// fingerprint of "null" from the Avro test suite
+	assert.Equal(t, uint64(0x63dd24e7cc258f8a), Fingerprint64(Null()))
+}
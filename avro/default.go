@@ -0,0 +1,11 @@
+package avro
+
+// NullDefault is the default value of fields that default to null.
+// A Field with a nil Default has no default value.
+var NullDefault = nullDefault{}
+
+type nullDefault struct{}
+
+func (nullDefault) MarshalJSON() ([]byte, error) {
+	return []byte("null"), nil
+}
@@ -0,0 +1,327 @@
+package avro
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Protocol is an Avro protocol declaring named types.
+// Protocol messages are not supported.
+type Protocol struct {
+	Name      string
+	Namespace string
+	Doc       string
+	// Types are the named types of the protocol, or nullable unions of named types.
+	// Named types that are declared by more than one schema are only declared once.
+	Types []Schema
+}
+
+// MarshalIDL returns the Avro IDL declaration of the protocol.
+// See: https://avro.apache.org/docs/current/idl-language/
+func (p Protocol) MarshalIDL() ([]byte, error) {
+	w := idlWriter{namespace: p.Namespace, declared: make(map[string]struct{}), empty: true}
+	w.doc(p.Doc, "")
+	if p.Namespace != "" {
+		fmt.Fprintf(&w.b, "@namespace(%s)\n", quote(p.Namespace))
+	}
+	fmt.Fprintf(&w.b, "protocol %s {\n", identifier(p.Name))
+	for _, schema := range p.Types {
+		if err := w.declareAll(schema, p.Namespace); err != nil {
+			return nil, err
+		}
+	}
+	w.b.WriteString("}\n")
+	return w.b.Bytes(), nil
+}
+
+// idlKeywords are the reserved words of Avro IDL, which are escaped when used as identifiers.
+var idlKeywords = map[string]struct{}{
+	"array": {}, "boolean": {}, "bytes": {}, "date": {}, "decimal": {}, "double": {}, "enum": {},
+	"error": {}, "false": {}, "fixed": {}, "float": {}, "idl": {}, "import": {}, "int": {},
+	"local_timestamp_ms": {}, "long": {}, "map": {}, "null": {}, "oneway": {}, "protocol": {},
+	"record": {}, "schema": {}, "string": {}, "throws": {}, "time_ms": {}, "timestamp_ms": {},
+	"true": {}, "union": {}, "uuid": {}, "void": {},
+}
+
+func identifier(name string) string {
+	if _, ok := idlKeywords[name]; ok {
+		return "`" + name + "`"
+	}
+	return name
+}
+
+func quote(s string) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
+
+type idlWriter struct {
+	b         bytes.Buffer
+	namespace string
+	// empty is whether no declarations have been written yet.
+	empty bool
+	// declared holds the full names of the declared named types.
+	declared map[string]struct{}
+}
+
+func (w *idlWriter) doc(doc string, indent string) {
+	if doc == "" {
+		return
+	}
+	doc = strings.ReplaceAll(doc, "*/", "*\\/")
+	lines := strings.Split(strings.TrimRight(doc, "\n"), "\n")
+	if len(lines) == 1 {
+		fmt.Fprintf(&w.b, "%s/** %s */\n", indent, strings.TrimSpace(lines[0]))
+		return
+	}
+	fmt.Fprintf(&w.b, "%s/**\n", indent)
+	for _, line := range lines {
+		fmt.Fprintf(&w.b, "%s * %s\n", indent, strings.TrimSpace(line))
+	}
+	fmt.Fprintf(&w.b, "%s */\n", indent)
+}
+
+// declareAll declares the named types in schema, with the types they
+// depend on declared before them.
+func (w *idlWriter) declareAll(schema Schema, namespace string) error {
+	switch s := schema.(type) {
+	case Union:
+		for _, branch := range s {
+			if err := w.declareAll(branch, namespace); err != nil {
+				return err
+			}
+		}
+	case Array:
+		return w.declareAll(s.Items, namespace)
+	case Map:
+		return w.declareAll(s.Values, namespace)
+	case Record:
+		full := fullName(s.Namespace, s.Name, namespace)
+		if _, ok := w.declared[full]; ok {
+			return nil
+		}
+		w.declared[full] = struct{}{}
+		for _, field := range s.Fields {
+			if err := w.declareAll(field.Type, namespaceOf(full)); err != nil {
+				return err
+			}
+		}
+		return w.record(s, full)
+	case Enum:
+		full := fullName(s.Namespace, s.Name, namespace)
+		if _, ok := w.declared[full]; ok {
+			return nil
+		}
+		w.declared[full] = struct{}{}
+		w.begin(s.Doc)
+		if err := w.annotations(namespaceOf(full), nil, s.Props, "  "); err != nil {
+			return fmt.Errorf("enum %s: %w", full, err)
+		}
+		symbols := make([]string, 0, len(s.Symbols))
+		for _, symbol := range s.Symbols {
+			symbols = append(symbols, identifier(symbol))
+		}
+		fmt.Fprintf(&w.b, "  enum %s {\n    %s\n  }", identifier(s.Name), strings.Join(symbols, ",\n    "))
+		if s.Default != "" {
+			fmt.Fprintf(&w.b, " = %s;", identifier(s.Default))
+		}
+		w.b.WriteString("\n")
+	case Fixed:
+		full := fullName(s.Namespace, s.Name, namespace)
+		if _, ok := w.declared[full]; ok {
+			return nil
+		}
+		w.declared[full] = struct{}{}
+		w.begin("")
+		if err := w.annotations(namespaceOf(full), nil, s.Props, "  "); err != nil {
+			return fmt.Errorf("fixed %s: %w", full, err)
+		}
+		fmt.Fprintf(&w.b, "  fixed %s(%d);\n", identifier(s.Name), s.Size)
+	}
+	return nil
+}
+
+// begin starts the declaration of a named type.
+func (w *idlWriter) begin(doc string) {
+	if !w.empty {
+		// separate declarations with an empty line
+		w.b.WriteString("\n")
+	}
+	w.empty = false
+	w.doc(doc, "  ")
+}
+
+// annotations writes the namespace annotation of named types outside the
+// protocol namespace, the aliases annotation and the annotations of the properties.
+func (w *idlWriter) annotations(
+	namespace string,
+	aliases []string,
+	props map[string]interface{},
+	indent string,
+) error {
+	if namespace != w.namespace {
+		fmt.Fprintf(&w.b, "%s@namespace(%s)\n", indent, quote(namespace))
+	}
+	if len(aliases) > 0 {
+		b, _ := json.Marshal(aliases)
+		fmt.Fprintf(&w.b, "%s@aliases(%s)\n", indent, b)
+	}
+	annotations, err := propAnnotations(props)
+	if err != nil {
+		return err
+	}
+	for _, annotation := range annotations {
+		fmt.Fprintf(&w.b, "%s%s\n", indent, annotation)
+	}
+	return nil
+}
+
+// propAnnotations returns the annotations of the properties, sorted by name.
+func propAnnotations(props map[string]interface{}) ([]string, error) {
+	names := make([]string, 0, len(props))
+	for name := range props {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	annotations := make([]string, 0, len(names))
+	for _, name := range names {
+		value, err := json.Marshal(props[name])
+		if err != nil {
+			return nil, fmt.Errorf("property '%s': %w", name, err)
+		}
+		annotations = append(annotations, fmt.Sprintf("@%s(%s)", name, value))
+	}
+	return annotations, nil
+}
+
+func (w *idlWriter) record(r Record, full string) error {
+	w.begin(r.Doc)
+	if err := w.annotations(namespaceOf(full), r.Aliases, r.Props, "  "); err != nil {
+		return fmt.Errorf("record %s: %w", full, err)
+	}
+	fmt.Fprintf(&w.b, "  record %s {\n", identifier(r.Name))
+	for _, field := range r.Fields {
+		typ, err := w.reference(field.Type, namespaceOf(full))
+		if err != nil {
+			return fmt.Errorf("record %s: field %s: %w", full, field.Name, err)
+		}
+		w.doc(field.Doc, "    ")
+		fmt.Fprintf(&w.b, "    %s ", typ)
+		if len(field.Aliases) > 0 {
+			b, _ := json.Marshal(field.Aliases)
+			fmt.Fprintf(&w.b, "@aliases(%s) ", b)
+		}
+		if field.FieldID != 0 {
+			fmt.Fprintf(&w.b, "@field-id(%d) ", field.FieldID)
+		}
+		annotations, err := propAnnotations(field.Props)
+		if err != nil {
+			return fmt.Errorf("record %s: field %s: %w", full, field.Name, err)
+		}
+		for _, annotation := range annotations {
+			fmt.Fprintf(&w.b, "%s ", annotation)
+		}
+		w.b.WriteString(identifier(field.Name))
+		if field.Default != nil {
+			value, err := json.Marshal(field.Default)
+			if err != nil {
+				return fmt.Errorf("record %s: field %s: default: %w", full, field.Name, err)
+			}
+			fmt.Fprintf(&w.b, " = %s", value)
+		}
+		w.b.WriteString(";\n")
+	}
+	w.b.WriteString("  }\n")
+	return nil
+}
+
+// reference returns the IDL type of schema, where named types are referenced by name.
+func (w *idlWriter) reference(schema Schema, namespace string) (string, error) {
+	switch s := schema.(type) {
+	case Reference:
+		return w.name(fullName("", string(s), namespace)), nil
+	case Primitive:
+		annotations, err := propAnnotations(s.Props)
+		if err != nil {
+			return "", err
+		}
+		var prefix string
+		for _, annotation := range annotations {
+			prefix += annotation + " "
+		}
+		switch s.LogicalType {
+		case "":
+			return prefix + string(s.Type), nil
+		case DateLogicalType:
+			if s.Type == IntType {
+				return prefix + "date", nil
+			}
+		}
+		return fmt.Sprintf("%s@logicalType(%s) %s", prefix, quote(string(s.LogicalType)), s.Type), nil
+	case Union:
+		branches := make([]string, 0, len(s))
+		for _, branch := range s {
+			typ, err := w.reference(branch, namespace)
+			if err != nil {
+				return "", err
+			}
+			branches = append(branches, typ)
+		}
+		return "union { " + strings.Join(branches, ", ") + " }", nil
+	case Array:
+		items, err := w.reference(s.Items, namespace)
+		if err != nil {
+			return "", err
+		}
+		var annotations string
+		if s.LogicalType != "" {
+			annotations += fmt.Sprintf("@logicalType(%s) ", quote(string(s.LogicalType)))
+		}
+		if s.ElementID != 0 {
+			annotations += fmt.Sprintf("@element-id(%d) ", s.ElementID)
+		}
+		if s.KeyID != 0 {
+			annotations += fmt.Sprintf("@key-id(%d) ", s.KeyID)
+		}
+		if s.ValueID != 0 {
+			annotations += fmt.Sprintf("@value-id(%d) ", s.ValueID)
+		}
+		props, err := propAnnotations(s.Props)
+		if err != nil {
+			return "", err
+		}
+		for _, annotation := range props {
+			annotations += annotation + " "
+		}
+		return annotations + "array<" + items + ">", nil
+	case Map:
+		values, err := w.reference(s.Values, namespace)
+		if err != nil {
+			return "", err
+		}
+		return "map<" + values + ">", nil
+	case Record:
+		return w.name(fullName(s.Namespace, s.Name, namespace)), nil
+	case Enum:
+		return w.name(fullName(s.Namespace, s.Name, namespace)), nil
+	case Fixed:
+		return w.name(fullName(s.Namespace, s.Name, namespace)), nil
+	}
+	return "", fmt.Errorf("unsupported schema %T", schema)
+}
+
+// name returns the reference to a named type, which is relative
+// to the protocol namespace when the type is in it.
+func (w *idlWriter) name(full string) string {
+	if namespaceOf(full) == w.namespace {
+		return identifier(strings.TrimPrefix(full, w.namespace+"."))
+	}
+	parts := strings.Split(full, ".")
+	for i, part := range parts {
+		parts[i] = identifier(part)
+	}
+	return strings.Join(parts, ".")
+}
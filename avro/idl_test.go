@@ -0,0 +1,96 @@
+package avro
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestProtocol_MarshalIDL(t *testing.T) {
+	t.Parallel()
+	genre := Enum{
+		Type:      EnumType,
+		Namespace: "google.example.library.v1.Book",
+		Name:      "Genre",
+		Symbols:   []string{"GENRE_UNSPECIFIED", "FICTION"},
+		Default:   "GENRE_UNSPECIFIED",
+	}
+	book := Record{
+		Type:      RecordType,
+		Namespace: "google.example.library.v1",
+		Name:      "Book",
+		Doc:       "A book.\nIn a library.",
+		Aliases:   []string{"Volume"},
+		Props:     map[string]interface{}{"x-owner": "library"},
+		Fields: []Field{
+			{
+				Name:    "name",
+				Doc:     "The name.",
+				Type:    Nullable(String()),
+				Default: NullDefault,
+				Props:   map[string]interface{}{"x-behavior": []string{"REQUIRED"}},
+			},
+			{Name: "genre", Type: Nullable(genre), Default: NullDefault},
+			{Name: "record", Aliases: []string{"entry"}, Type: String(), Default: ""},
+			{Name: "published", Type: Nullable(TimestampMicros()), Default: NullDefault},
+			{Name: "published_date", Type: Date()},
+			{Name: "tags", Type: Array{Type: ArrayType, Items: String()}, Default: []interface{}{}},
+			{Name: "ratings", Type: Map{Type: MapType, Values: Double()}},
+			{Name: "checksum", Type: Fixed{Type: FixedType, Namespace: "google.example.library.v1", Name: "MD5", Size: 16}},
+			{Name: "sequel", Type: Nullable(Reference("google.example.library.v1.Book")), Default: NullDefault},
+		},
+	}
+	shelf := Record{
+		Type:      RecordType,
+		Namespace: "google.example.library.v1",
+		Name:      "Shelf",
+		Fields: []Field{
+			{Name: "books", Type: Array{Type: ArrayType, Items: book}},
+			{Name: "book_ids", FieldID: 2, Type: Array{Type: ArrayType, Items: Long(), ElementID: 3}},
+		},
+	}
+	protocol := Protocol{
+		Name:      "Library",
+		Namespace: "google.example.library.v1",
+		Doc:       "The library protocol.",
+		Types:     []Schema{Nullable(book), shelf},
+	}
+	got, err := protocol.MarshalIDL()
+	assert.NilError(t, err)
+	assert.Equal(t, `/** The library protocol. */
+@namespace("google.example.library.v1")
+protocol Library {
+  @namespace("google.example.library.v1.Book")
+  enum Genre {
+    GENRE_UNSPECIFIED,
+    FICTION
+  } = GENRE_UNSPECIFIED;
+
+  fixed MD5(16);
+
+  /**
+   * A book.
+   * In a library.
+   */
+  @aliases(["Volume"])
+  @x-owner("library")
+  record Book {
+    /** The name. */
+    union { null, string } @x-behavior(["REQUIRED"]) name = null;
+    union { null, google.example.library.v1.Book.Genre } genre = null;
+    string @aliases(["entry"]) `+"`record`"+` = "";
+    union { null, @logicalType("timestamp-micros") long } published = null;
+    date published_date;
+    array<string> tags = [];
+    map<double> ratings;
+    MD5 checksum;
+    union { null, Book } sequel = null;
+  }
+
+  record Shelf {
+    array<Book> books;
+    @element-id(3) array<long> @field-id(2) book_ids;
+  }
+}
+`, string(got))
+}
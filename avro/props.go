@@ -0,0 +1,112 @@
+package avro
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// The attributes of the schemas, which properties can not replace, also when they are omitted.
+// They include the attributes of the Avro specification that the types have no fields for.
+var (
+	primitiveAttributes = []string{"type", "logicalType", "precision", "scale"}
+	recordAttributes    = []string{"type", "namespace", "doc", "name", "aliases", "fields"}
+	fieldAttributes     = []string{"name", "doc", "aliases", "field-id", "type", "default", "order"}
+	enumAttributes      = []string{"type", "namespace", "doc", "name", "aliases", "symbols", "default"}
+	arrayAttributes     = []string{"type", "logicalType", "items", "element-id", "key-id", "value-id"}
+	fixedAttributes     = []string{"type", "name", "namespace", "aliases", "size", "logicalType", "precision", "scale"}
+)
+
+// marshalProps merges the properties into the JSON object of the schema attributes,
+// after the attributes and sorted by name. Properties can not replace reserved attributes.
+func marshalProps(attributes []byte, reserved []string, props map[string]interface{}) ([]byte, error) {
+	if len(props) == 0 {
+		return attributes, nil
+	}
+	var fixed map[string]json.RawMessage
+	if err := json.Unmarshal(attributes, &fixed); err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(props))
+	for name := range props {
+		for _, attribute := range reserved {
+			if name == attribute {
+				return nil, fmt.Errorf("property '%s' conflicts with an attribute", name)
+			}
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var b bytes.Buffer
+	b.Write(bytes.TrimSuffix(bytes.TrimSpace(attributes), []byte("}")))
+	for i, name := range names {
+		value, err := json.Marshal(props[name])
+		if err != nil {
+			return nil, fmt.Errorf("property '%s': %w", name, err)
+		}
+		if i > 0 || len(fixed) > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(quote(name))
+		b.WriteByte(':')
+		b.Write(value)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// MarshalJSON implements json.Marshaler.
+// Props are merged into the attributes.
+func (p Primitive) MarshalJSON() ([]byte, error) {
+	type primitive Primitive
+	b, err := json.Marshal(primitive(p))
+	if err != nil {
+		return nil, err
+	}
+	return marshalProps(b, primitiveAttributes, p.Props)
+}
+
+// MarshalJSON implements json.Marshaler.
+// Props are merged into the attributes.
+func (p Record) MarshalJSON() ([]byte, error) {
+	type record Record
+	b, err := json.Marshal(record(p))
+	if err != nil {
+		return nil, err
+	}
+	return marshalProps(b, recordAttributes, p.Props)
+}
+
+// MarshalJSON implements json.Marshaler.
+// Props are merged into the attributes.
+func (e Enum) MarshalJSON() ([]byte, error) {
+	type enum Enum
+	b, err := json.Marshal(enum(e))
+	if err != nil {
+		return nil, err
+	}
+	return marshalProps(b, enumAttributes, e.Props)
+}
+
+// MarshalJSON implements json.Marshaler.
+// Props are merged into the attributes.
+func (e Array) MarshalJSON() ([]byte, error) {
+	type array Array
+	b, err := json.Marshal(array(e))
+	if err != nil {
+		return nil, err
+	}
+	return marshalProps(b, arrayAttributes, e.Props)
+}
+
+// MarshalJSON implements json.Marshaler.
+// Props are merged into the attributes.
+func (e Fixed) MarshalJSON() ([]byte, error) {
+	type fixed Fixed
+	b, err := json.Marshal(fixed(e))
+	if err != nil {
+		return nil, err
+	}
+	return marshalProps(b, fixedAttributes, e.Props)
+}
@@ -0,0 +1,112 @@
+package avro
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestProps_MarshalJSON(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		name     string
+		schema   interface{}
+		expected string
+	}{
+		{
+			name:     "primitive",
+			schema:   Primitive{Type: StringType, Props: map[string]interface{}{"x-b": 1, "x-a": "a"}},
+			expected: `{"type":"string","x-a":"a","x-b":1}`,
+		},
+		{
+			name:     "primitive without props",
+			schema:   String(),
+			expected: `{"type":"string"}`,
+		},
+		{
+			name: "field with default",
+			schema: Field{
+				Name:    "name",
+				Type:    Nullable(String()),
+				Default: NullDefault,
+				Props:   map[string]interface{}{"x-owner": "library"},
+			},
+			expected: `{"name":"name","type":[{"type":"null"},{"type":"string"}],"default":null,"x-owner":"library"}`,
+		},
+		{
+			name: "record",
+			schema: Record{
+				Type:   RecordType,
+				Name:   "Book",
+				Fields: []Field{},
+				Props:  map[string]interface{}{"x-classification": []string{"internal"}},
+			},
+			expected: `{"type":"record","name":"Book","fields":[],"x-classification":["internal"]}`,
+		},
+		{
+			name: "array",
+			schema: Array{
+				Type:  ArrayType,
+				Items: Long(),
+				Props: map[string]interface{}{"x-unique": true},
+			},
+			expected: `{"type":"array","items":{"type":"long"},"x-unique":true}`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := json.Marshal(tt.schema)
+			assert.NilError(t, err)
+			assert.Equal(t, tt.expected, string(got))
+		})
+	}
+}
+
+func TestProps_Conflict(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		name     string
+		schema   interface{}
+		property string
+	}{
+		{
+			name: "emitted attribute",
+			schema: Enum{
+				Type:    EnumType,
+				Name:    "Genre",
+				Symbols: []string{"FICTION"},
+				Props:   map[string]interface{}{"symbols": []string{"POETRY"}},
+			},
+			property: "symbols",
+		},
+		{
+			name:     "omitted doc",
+			schema:   Record{Type: RecordType, Name: "Book", Props: map[string]interface{}{"doc": "A book."}},
+			property: "doc",
+		},
+		{
+			name:     "omitted default",
+			schema:   Field{Name: "title", Type: String(), Props: map[string]interface{}{"default": ""}},
+			property: "default",
+		},
+		{
+			name:     "omitted aliases",
+			schema:   Enum{Type: EnumType, Name: "Genre", Props: map[string]interface{}{"aliases": []string{"Kind"}}},
+			property: "aliases",
+		},
+		{
+			name:     "omitted logical type",
+			schema:   Primitive{Type: StringType, Props: map[string]interface{}{"logicalType": "uuid"}},
+			property: "logicalType",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := json.Marshal(tt.schema)
+			assert.ErrorContains(t, err, "property '"+tt.property+"' conflicts with an attribute")
+		})
+	}
+}
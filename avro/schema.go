@@ -2,6 +2,8 @@
 // to spec at http://avro.apache.org/docs/current/spec.html.
 package avro
 
+import "encoding/json"
+
 // Schema describes an Avro schema.
 // JSON encoding of a Schema value matches the specification
 // for a schema declaration.
@@ -26,6 +28,8 @@ const (
 	RecordType  Type = "record"
 	EnumType    Type = "enum"
 	ArrayType   Type = "array"
+	FixedType   Type = "fixed"
+	MapType     Type = "map"
 )
 
 // LogicalType is an Avro primitive or complex type with extra attributes to represent a derived type.
@@ -36,6 +40,13 @@ const (
 	DateLogicalType            LogicalType = "date"
 	TimeMicrosLogicalType      LogicalType = "time-micros"
 	TimestampMicrosLogicalType LogicalType = "timestamp-micros"
+	UUIDLogicalType            LogicalType = "uuid"
+	// DateTimeLogicalType is not part of the Avro specification, but is used by BigQuery
+	// for civil date and time strings.
+	DateTimeLogicalType LogicalType = "datetime"
+	// MapLogicalType is not part of the Avro specification, but is used by Apache Iceberg
+	// for maps encoded as arrays of records with key and value fields.
+	MapLogicalType LogicalType = "map"
 )
 
 type Reference string
@@ -49,6 +60,9 @@ func (e Union) isSchema() {}
 type Primitive struct {
 	Type        Type        `json:"type"`
 	LogicalType LogicalType `json:"logicalType,omitempty"`
+	// Props are attributes that are not part of the Avro specification, merged inline
+	// into the JSON encoding of the schema.
+	Props map[string]interface{} `json:"-"`
 }
 
 func (p Primitive) isSchema() {}
@@ -86,19 +100,52 @@ func Boolean() Primitive {
 }
 
 type Record struct {
-	Type      Type    `json:"type"`
-	Namespace string  `json:"namespace,omitempty"`
-	Doc       string  `json:"doc,omitempty"`
-	Name      string  `json:"name"`
-	Fields    []Field `json:"fields"`
+	Type      Type     `json:"type"`
+	Namespace string   `json:"namespace,omitempty"`
+	Doc       string   `json:"doc,omitempty"`
+	Name      string   `json:"name"`
+	Aliases   []string `json:"aliases,omitempty"`
+	Fields    []Field  `json:"fields"`
+	// Props are attributes that are not part of the Avro specification, merged inline
+	// into the JSON encoding of the schema.
+	Props map[string]interface{} `json:"-"`
 }
 
 func (p Record) isSchema() {}
 
 type Field struct {
-	Name string `json:"name"`
-	Doc  string `json:"doc,omitempty"`
-	Type Schema `json:"type"`
+	Name    string   `json:"name"`
+	Doc     string   `json:"doc,omitempty"`
+	Aliases []string `json:"aliases,omitempty"`
+	// FieldID is the field-id attribute used by Apache Iceberg, omitted when zero.
+	FieldID int    `json:"field-id,omitempty"`
+	Type    Schema `json:"type"`
+	// Default is the default value of the field, in the JSON form of the field type.
+	// Use NullDefault for fields that default to null.
+	Default interface{} `json:"-"`
+	// Props are attributes that are not part of the Avro specification, merged inline
+	// into the JSON encoding of the schema.
+	Props map[string]interface{} `json:"-"`
+}
+
+// MarshalJSON implements json.Marshaler.
+// The default value is only included when Default is not nil, and Props are merged into the attributes.
+func (f Field) MarshalJSON() ([]byte, error) {
+	type field Field
+	var b []byte
+	var err error
+	if f.Default == nil {
+		b, err = json.Marshal(field(f))
+	} else {
+		b, err = json.Marshal(struct {
+			field
+			Default interface{} `json:"default"`
+		}{field: field(f), Default: f.Default})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return marshalProps(b, fieldAttributes, f.Props)
 }
 
 type Enum struct {
@@ -107,22 +154,47 @@ type Enum struct {
 	Doc       string   `json:"doc,omitempty"`
 	Name      string   `json:"name"`
 	Symbols   []string `json:"symbols"`
+	// Default is the symbol used by readers for symbols not in Symbols.
+	Default string `json:"default,omitempty"`
+	// Props are attributes that are not part of the Avro specification, merged inline
+	// into the JSON encoding of the schema.
+	Props map[string]interface{} `json:"-"`
 }
 
 func (e Enum) isSchema() {}
 
 type Array struct {
-	Type  Type   `json:"type"`
-	Items Schema `json:"items"`
+	Type        Type        `json:"type"`
+	LogicalType LogicalType `json:"logicalType,omitempty"`
+	Items       Schema      `json:"items"`
+	// ElementID is the element-id attribute used by Apache Iceberg, omitted when zero.
+	ElementID int `json:"element-id,omitempty"`
+	// KeyID and ValueID are the key-id and value-id attributes used by Apache Iceberg for
+	// arrays of map entries, omitted when zero.
+	KeyID   int `json:"key-id,omitempty"`
+	ValueID int `json:"value-id,omitempty"`
+	// Props are attributes that are not part of the Avro specification, merged inline
+	// into the JSON encoding of the schema.
+	Props map[string]interface{} `json:"-"`
 }
 
 func (e Array) isSchema() {}
 
+type Map struct {
+	Type   Type   `json:"type"`
+	Values Schema `json:"values"`
+}
+
+func (e Map) isSchema() {}
+
 type Fixed struct {
 	Type      Type   `json:"type"`
 	Name      string `json:"name"`
 	Namespace string `json:"namespace,omitempty"`
 	Size      int    `json:"size"`
+	// Props are attributes that are not part of the Avro specification, merged inline
+	// into the JSON encoding of the schema.
+	Props map[string]interface{} `json:"-"`
 }
 
 func (e Fixed) isSchema() {}
@@ -148,11 +220,30 @@ func TimestampMicros() Primitive {
 	}
 }
 
+func UUID() Primitive {
+	return Primitive{
+		Type:        StringType,
+		LogicalType: UUIDLogicalType,
+	}
+}
+
+func DateTime() Primitive {
+	return Primitive{
+		Type:        StringType,
+		LogicalType: DateTimeLogicalType,
+	}
+}
+
+func isNull(schema Schema) bool {
+	p, ok := schema.(Primitive)
+	return ok && p.Type == NullType
+}
+
 func Nullable(schema Schema) Union {
 	if union, ok := schema.(Union); ok {
 		var found bool
 		for _, v := range union {
-			if v == Null() {
+			if isNull(v) {
 				found = true
 			}
 		}
@@ -0,0 +1,264 @@
+package avro
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ValidationError describes a part of a schema that violates the Avro specification.
+type ValidationError struct {
+	// Path identifies the part of the schema, starting from the root schema,
+	// for example "Book.fields.title.type[1]".
+	Path    string
+	Message string
+}
+
+// Error implements error.
+func (e ValidationError) Error() string {
+	return e.Path + ": " + e.Message
+}
+
+// ValidationErrors are the errors found when validating a schema.
+type ValidationErrors []ValidationError
+
+// Error implements error.
+func (e ValidationErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+	return "invalid schema: " + strings.Join(messages, "; ")
+}
+
+// Validate checks a schema against the Avro specification, and returns
+// ValidationErrors describing the violations, or nil if the schema is valid.
+// See: https://avro.apache.org/docs/current/specification/
+func Validate(schema Schema) error {
+	v := validator{defined: make(map[string]struct{})}
+	path := "schema"
+	if name := schemaName(schema); name != "" {
+		path = name
+	}
+	v.validate(schema, "", path)
+	if len(v.errs) > 0 {
+		return v.errs
+	}
+	return nil
+}
+
+var nameRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+type validator struct {
+	// defined holds the full names of the named types defined so far.
+	defined map[string]struct{}
+	errs    ValidationErrors
+}
+
+func (v *validator) errorf(path string, format string, args ...interface{}) {
+	v.errs = append(v.errs, ValidationError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// validate validates the schema at path, where namespace is the enclosing namespace.
+func (v *validator) validate(schema Schema, namespace string, path string) {
+	switch s := schema.(type) {
+	case nil:
+		v.errorf(path, "missing schema")
+	case Reference:
+		if _, ok := v.defined[v.resolve(string(s), namespace)]; !ok {
+			v.errorf(path, "undefined type '%s'", s)
+		}
+	case Primitive:
+		v.validatePrimitive(s, path)
+	case Union:
+		v.validateUnion(s, namespace, path)
+	case Array:
+		if s.Type != ArrayType {
+			v.errorf(path, "expected type '%s', got '%s'", ArrayType, s.Type)
+		}
+		v.validate(s.Items, namespace, path+".items")
+	case Map:
+		if s.Type != MapType {
+			v.errorf(path, "expected type '%s', got '%s'", MapType, s.Type)
+		}
+		v.validate(s.Values, namespace, path+".values")
+	case Record:
+		if s.Type != RecordType {
+			v.errorf(path, "expected type '%s', got '%s'", RecordType, s.Type)
+		}
+		ns := v.define(s.Namespace, s.Name, namespace, path)
+		v.validateAliases(s.Aliases, path)
+		fields := make(map[string]struct{}, len(s.Fields))
+		for _, field := range s.Fields {
+			fieldPath := path + ".fields." + field.Name
+			v.validateName(field.Name, fieldPath)
+			if _, ok := fields[field.Name]; ok {
+				v.errorf(fieldPath, "duplicate field '%s'", field.Name)
+			}
+			fields[field.Name] = struct{}{}
+			v.validateAliases(field.Aliases, fieldPath)
+			v.validate(field.Type, ns, fieldPath+".type")
+		}
+	case Enum:
+		if s.Type != EnumType {
+			v.errorf(path, "expected type '%s', got '%s'", EnumType, s.Type)
+		}
+		v.define(s.Namespace, s.Name, namespace, path)
+		symbols := make(map[string]struct{}, len(s.Symbols))
+		for _, symbol := range s.Symbols {
+			v.validateName(symbol, path+".symbols")
+			if _, ok := symbols[symbol]; ok {
+				v.errorf(path+".symbols", "duplicate symbol '%s'", symbol)
+			}
+			symbols[symbol] = struct{}{}
+		}
+		if _, ok := symbols[s.Default]; s.Default != "" && !ok {
+			v.errorf(path+".default", "default '%s' is not a symbol", s.Default)
+		}
+	case Fixed:
+		if s.Type != FixedType {
+			v.errorf(path, "expected type '%s', got '%s'", FixedType, s.Type)
+		}
+		v.define(s.Namespace, s.Name, namespace, path)
+		if s.Size < 0 {
+			v.errorf(path+".size", "invalid size %d", s.Size)
+		}
+	default:
+		v.errorf(path, "unsupported schema %T", schema)
+	}
+}
+
+func (v *validator) validatePrimitive(p Primitive, path string) {
+	switch p.Type {
+	case NullType, BooleanType, IntType, LongType, FloatType, DoubleType, BytesType, StringType:
+	default:
+		v.errorf(path, "invalid primitive type '%s'", p.Type)
+		return
+	}
+	var expected Type
+	switch p.LogicalType {
+	case "":
+		return
+	case DateLogicalType:
+		expected = IntType
+	case TimeMicrosLogicalType, TimestampMicrosLogicalType:
+		expected = LongType
+	case UUIDLogicalType, DateTimeLogicalType:
+		expected = StringType
+	default:
+		// unknown logical types are ignored by readers
+		return
+	}
+	if p.Type != expected {
+		v.errorf(path, "logical type '%s' requires type '%s', got '%s'", p.LogicalType, expected, p.Type)
+	}
+}
+
+func (v *validator) validateUnion(u Union, namespace string, path string) {
+	branches := make(map[string]struct{}, len(u))
+	for i, branch := range u {
+		branchPath := path + "[" + strconv.Itoa(i) + "]"
+		if _, ok := branch.(Union); ok {
+			v.errorf(branchPath, "unions may not immediately contain other unions")
+			continue
+		}
+		v.validate(branch, namespace, branchPath)
+		key := v.branchKey(branch, namespace)
+		if _, ok := branches[key]; ok {
+			v.errorf(branchPath, "duplicate union branch '%s'", key)
+		}
+		branches[key] = struct{}{}
+	}
+}
+
+// branchKey returns the key that identifies a union branch, which is the
+// full name of named types and the type of other types.
+func (v *validator) branchKey(schema Schema, namespace string) string {
+	switch s := schema.(type) {
+	case Reference:
+		return v.resolve(string(s), namespace)
+	case Primitive:
+		return string(s.Type)
+	case Array:
+		return string(ArrayType)
+	case Map:
+		return string(MapType)
+	case Record:
+		return fullName(s.Namespace, s.Name, namespace)
+	case Enum:
+		return fullName(s.Namespace, s.Name, namespace)
+	case Fixed:
+		return fullName(s.Namespace, s.Name, namespace)
+	}
+	return fmt.Sprintf("%T", schema)
+}
+
+// define registers the named type and returns its namespace,
+// which is the enclosing namespace of the schemas it contains.
+func (v *validator) define(namespace, name, enclosing string, path string) string {
+	v.validateName(name, path+".name")
+	if namespace != "" {
+		for _, part := range strings.Split(namespace, ".") {
+			v.validateName(part, path+".namespace")
+		}
+	}
+	full := fullName(namespace, name, enclosing)
+	if _, ok := v.defined[full]; ok {
+		v.errorf(path, "duplicate definition of type '%s'", full)
+	}
+	v.defined[full] = struct{}{}
+	return namespaceOf(full)
+}
+
+func (v *validator) validateName(name string, path string) {
+	if !nameRegexp.MatchString(name) {
+		v.errorf(path, "invalid name '%s'", name)
+	}
+}
+
+func (v *validator) validateAliases(aliases []string, path string) {
+	for _, alias := range aliases {
+		for _, part := range strings.Split(alias, ".") {
+			v.validateName(part, path+".aliases")
+		}
+	}
+}
+
+// resolve returns the full name of a reference to a named type.
+func (v *validator) resolve(name, namespace string) string {
+	if strings.Contains(name, ".") || namespace == "" {
+		return name
+	}
+	if _, ok := v.defined[name]; ok {
+		return name
+	}
+	return namespace + "." + name
+}
+
+// fullName returns the full name of a named type, which is in the enclosing
+// namespace unless the name or namespace are given.
+func fullName(namespace, name, enclosing string) string {
+	switch {
+	case strings.Contains(name, "."):
+		return name
+	case namespace != "":
+		return namespace + "." + name
+	case enclosing != "":
+		return enclosing + "." + name
+	}
+	return name
+}
+
+// schemaName returns the name of a named schema, for use as the root of validation paths.
+func schemaName(schema Schema) string {
+	switch s := schema.(type) {
+	case Record:
+		return s.Name
+	case Enum:
+		return s.Name
+	case Fixed:
+		return s.Name
+	}
+	return ""
+}
@@ -0,0 +1,160 @@
+package avro
+
+import (
+	"errors"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+	book := Record{
+		Type:      RecordType,
+		Name:      "Book",
+		Namespace: "google.example.library.v1",
+		Fields: []Field{
+			{Name: "name", Type: Nullable(String()), Default: NullDefault},
+			{Name: "published", Type: Nullable(TimestampMicros()), Default: NullDefault},
+			{Name: "next", Type: Nullable(Reference("google.example.library.v1.Book")), Default: NullDefault},
+		},
+	}
+	for _, tt := range []struct {
+		name     string
+		schema   Schema
+		expected ValidationErrors
+	}{
+		{
+			name:   "valid",
+			schema: Nullable(book),
+		},
+		{
+			name: "valid relative reference",
+			schema: Record{
+				Type:      RecordType,
+				Name:      "Shelf",
+				Namespace: "google.example.library.v1",
+				Fields: []Field{
+					{Name: "first", Type: book},
+					{Name: "second", Type: Reference("Book")},
+				},
+			},
+		},
+		{
+			name: "duplicate named type",
+			schema: Record{
+				Type: RecordType,
+				Name: "Shelf",
+				Fields: []Field{
+					{Name: "first", Type: book},
+					{Name: "second", Type: book},
+				},
+			},
+			expected: ValidationErrors{
+				{Path: "Shelf.fields.second.type", Message: "duplicate definition of type 'google.example.library.v1.Book'"},
+			},
+		},
+		{
+			name: "undefined reference",
+			schema: Record{
+				Type:   RecordType,
+				Name:   "Shelf",
+				Fields: []Field{{Name: "book", Type: Reference("google.example.library.v1.Book")}},
+			},
+			expected: ValidationErrors{
+				{Path: "Shelf.fields.book.type", Message: "undefined type 'google.example.library.v1.Book'"},
+			},
+		},
+		{
+			name: "invalid names",
+			schema: Record{
+				Type:      RecordType,
+				Name:      "Shelf",
+				Namespace: "google.1example",
+				Fields:    []Field{{Name: "book-name", Aliases: []string{"name.2"}, Type: String()}},
+			},
+			expected: ValidationErrors{
+				{Path: "Shelf.namespace", Message: "invalid name '1example'"},
+				{Path: "Shelf.fields.book-name", Message: "invalid name 'book-name'"},
+				{Path: "Shelf.fields.book-name.aliases", Message: "invalid name '2'"},
+			},
+		},
+		{
+			name: "duplicate fields",
+			schema: Record{
+				Type:   RecordType,
+				Name:   "Shelf",
+				Fields: []Field{{Name: "name", Type: String()}, {Name: "name", Type: String()}},
+			},
+			expected: ValidationErrors{
+				{Path: "Shelf.fields.name", Message: "duplicate field 'name'"},
+			},
+		},
+		{
+			name:   "duplicate union branches",
+			schema: Union{Null(), Long(), TimestampMicros()},
+			expected: ValidationErrors{
+				{Path: "schema[2]", Message: "duplicate union branch 'long'"},
+			},
+		},
+		{
+			name:   "nested union",
+			schema: Union{Null(), Union{String()}},
+			expected: ValidationErrors{
+				{Path: "schema[1]", Message: "unions may not immediately contain other unions"},
+			},
+		},
+		{
+			name:   "logical type on wrong type",
+			schema: Primitive{Type: StringType, LogicalType: TimestampMicrosLogicalType},
+			expected: ValidationErrors{
+				{Path: "schema", Message: "logical type 'timestamp-micros' requires type 'long', got 'string'"},
+			},
+		},
+		{
+			name:   "unknown logical type",
+			schema: Primitive{Type: StringType, LogicalType: "custom"},
+		},
+		{
+			name:   "bad fixed size",
+			schema: Fixed{Type: FixedType, Name: "id", Size: -1},
+			expected: ValidationErrors{
+				{Path: "id.size", Message: "invalid size -1"},
+			},
+		},
+		{
+			name: "enum",
+			schema: Enum{
+				Type:    EnumType,
+				Name:    "Enum",
+				Symbols: []string{"A", "A", "B-C"},
+				Default: "D",
+			},
+			expected: ValidationErrors{
+				{Path: "Enum.symbols", Message: "duplicate symbol 'A'"},
+				{Path: "Enum.symbols", Message: "invalid name 'B-C'"},
+				{Path: "Enum.default", Message: "default 'D' is not a symbol"},
+			},
+		},
+		{
+			name:   "array",
+			schema: Array{Type: ArrayType, Items: Reference("Book")},
+			expected: ValidationErrors{
+				{Path: "schema.items", Message: "undefined type 'Book'"},
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := Validate(tt.schema)
+			if tt.expected == nil {
+				assert.NilError(t, err)
+				return
+			}
+			var errs ValidationErrors
+			assert.Assert(t, errors.As(err, &errs))
+			assert.DeepEqual(t, tt.expected, errs)
+		})
+	}
+}
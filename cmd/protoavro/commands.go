@@ -0,0 +1,391 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/linkedin/goavro/v2"
+	"go.einride.tech/protobuf-avro/avro"
+	"go.einride.tech/protobuf-avro/encoding/protoavro"
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/prototext"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+const (
+	formatBinary    = "binary"
+	formatDelimited = "delimited"
+	formatJSON      = "json"
+	formatText      = "text"
+)
+
+func newFlagSet(name string) *flag.FlagSet {
+	return flag.NewFlagSet(name, flag.ContinueOnError)
+}
+
+func runSchema(args []string, _ io.Reader, stdout io.Writer) error {
+	var mf messageFlags
+	flags := newFlagSet("schema")
+	mf.register(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	messageType, _, err := mf.load()
+	if err != nil {
+		return err
+	}
+	schema, err := mf.opts.InferSchema(messageType.Descriptor())
+	if err != nil {
+		return fmt.Errorf("infer schema: %w", err)
+	}
+	if err := avro.Validate(schema); err != nil {
+		return fmt.Errorf("validate schema: %w", err)
+	}
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal schema: %w", err)
+	}
+	_, err = stdout.Write(append(data, '\n'))
+	return err
+}
+
+func runEncode(args []string, stdin io.Reader, stdout io.Writer) error {
+	var mf messageFlags
+	var inputFormat, out string
+	flags := newFlagSet("encode")
+	mf.register(flags)
+	flags.StringVar(&inputFormat, "input_format", formatJSON, "format of the input, binary, delimited, json or text")
+	flags.StringVar(&out, "out", "", "output file, defaults to stdout")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	messageType, types, err := mf.load()
+	if err != nil {
+		return err
+	}
+	read, err := messageReader(inputFormat, types)
+	if err != nil {
+		return err
+	}
+	return withOutput(out, stdout, func(w io.Writer) error {
+		marshaler, err := mf.opts.NewMarshaler(messageType.Descriptor(), w)
+		if err != nil {
+			return err
+		}
+		return forEachInput(flags.Args(), stdin, func(name string, r io.Reader) error {
+			return read(r, messageType, func(message proto.Message) error {
+				return marshaler.Marshal(message)
+			})
+		})
+	})
+}
+
+// messageReader returns a function that reads protobuf messages in the format from an input.
+// Binary and text inputs contain a single message, delimited and JSON inputs a stream of messages.
+func messageReader(
+	format string,
+	types *dynamicpb.Types,
+) (func(io.Reader, protoreflect.MessageType, func(proto.Message) error) error, error) {
+	switch format {
+	case formatBinary:
+		return func(r io.Reader, messageType protoreflect.MessageType, fn func(proto.Message) error) error {
+			data, err := io.ReadAll(r)
+			if err != nil {
+				return err
+			}
+			message := messageType.New().Interface()
+			if err := (proto.UnmarshalOptions{Resolver: types}).Unmarshal(data, message); err != nil {
+				return err
+			}
+			return fn(message)
+		}, nil
+	case formatText:
+		return func(r io.Reader, messageType protoreflect.MessageType, fn func(proto.Message) error) error {
+			data, err := io.ReadAll(r)
+			if err != nil {
+				return err
+			}
+			message := messageType.New().Interface()
+			if err := (prototext.UnmarshalOptions{Resolver: types}).Unmarshal(data, message); err != nil {
+				return err
+			}
+			return fn(message)
+		}, nil
+	case formatDelimited:
+		return func(r io.Reader, messageType protoreflect.MessageType, fn func(proto.Message) error) error {
+			br := bufio.NewReader(r)
+			for {
+				message := messageType.New().Interface()
+				// Resolver is promoted from the embedded proto.UnmarshalOptions
+				opts := protodelim.UnmarshalOptions{}
+				opts.Resolver = types
+				err := opts.UnmarshalFrom(br, message)
+				if errors.Is(err, io.EOF) {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+				if err := fn(message); err != nil {
+					return err
+				}
+			}
+		}, nil
+	case formatJSON:
+		return func(r io.Reader, messageType protoreflect.MessageType, fn func(proto.Message) error) error {
+			decoder := json.NewDecoder(r)
+			for {
+				var raw json.RawMessage
+				err := decoder.Decode(&raw)
+				if errors.Is(err, io.EOF) {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+				message := messageType.New().Interface()
+				if err := (protojson.UnmarshalOptions{Resolver: types}).Unmarshal(raw, message); err != nil {
+					return err
+				}
+				if err := fn(message); err != nil {
+					return err
+				}
+			}
+		}, nil
+	default:
+		return nil, fmt.Errorf("invalid input format '%s'", format)
+	}
+}
+
+func runDecode(args []string, stdin io.Reader, stdout io.Writer) error {
+	var mf messageFlags
+	var outputFormat, out string
+	flags := newFlagSet("decode")
+	mf.register(flags)
+	flags.StringVar(&outputFormat, "output_format", formatJSON, "format of the output, delimited or json")
+	flags.StringVar(&out, "out", "", "output file, defaults to stdout")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	messageType, types, err := mf.load()
+	if err != nil {
+		return err
+	}
+	var write func(io.Writer, proto.Message) error
+	switch outputFormat {
+	case formatJSON:
+		write = func(w io.Writer, message proto.Message) error {
+			data, err := protojson.MarshalOptions{Resolver: types}.Marshal(message)
+			if err != nil {
+				return err
+			}
+			_, err = w.Write(append(data, '\n'))
+			return err
+		}
+	case formatDelimited:
+		write = func(w io.Writer, message proto.Message) error {
+			_, err := protodelim.MarshalTo(w, message)
+			return err
+		}
+	default:
+		return fmt.Errorf("invalid output format '%s'", outputFormat)
+	}
+	return withOutput(out, stdout, func(w io.Writer) error {
+		return forEachInput(flags.Args(), stdin, func(name string, r io.Reader) error {
+			return unmarshalAll(mf.opts, r, messageType, func(message proto.Message) error {
+				return write(w, message)
+			})
+		})
+	})
+}
+
+// unmarshalAll unmarshals all records of an Object Container File to messages of the type.
+func unmarshalAll(
+	opts protoavro.SchemaOptions,
+	r io.Reader,
+	messageType protoreflect.MessageType,
+	fn func(proto.Message) error,
+) error {
+	unmarshaler, err := opts.NewUnmarshaler(r)
+	if err != nil {
+		return err
+	}
+	for i := 0; unmarshaler.Scan(); i++ {
+		message := messageType.New().Interface()
+		if err := unmarshaler.Unmarshal(message); err != nil {
+			return fmt.Errorf("record %d: %w", i, err)
+		}
+		if err := fn(message); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func runCat(args []string, stdin io.Reader, stdout io.Writer) error {
+	flags := newFlagSet("cat")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	return printRecords(flags.Args(), stdin, stdout, -1)
+}
+
+func runHead(args []string, stdin io.Reader, stdout io.Writer) error {
+	var n int
+	flags := newFlagSet("head")
+	flags.IntVar(&n, "n", 10, "number of records to print")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if n < 0 {
+		return fmt.Errorf("invalid number of records %d", n)
+	}
+	return printRecords(flags.Args(), stdin, stdout, n)
+}
+
+// printRecords prints the records of Object Container Files as JSON, one record per line.
+// When limit is not negative, at most limit records are printed.
+func printRecords(args []string, stdin io.Reader, stdout io.Writer, limit int) error {
+	var printed int
+	return forEachInput(args, stdin, func(name string, r io.Reader) error {
+		reader, err := goavro.NewOCFReader(r)
+		if err != nil {
+			return err
+		}
+		codec := reader.Codec()
+		for (limit < 0 || printed < limit) && reader.Scan() {
+			datum, err := reader.Read()
+			if err != nil {
+				return fmt.Errorf("record %d: %w", printed, err)
+			}
+			data, err := textual(codec, datum)
+			if err != nil {
+				return fmt.Errorf("record %d: %w", printed, err)
+			}
+			if _, err := stdout.Write(append(data, '\n')); err != nil {
+				return err
+			}
+			printed++
+		}
+		return reader.Err()
+	})
+}
+
+// textual returns the JSON encoding of an Avro datum. The fields of records are
+// sorted by name, since goavro encodes them in random order.
+func textual(codec *goavro.Codec, datum interface{}) ([]byte, error) {
+	data, err := codec.TextualFromNative(nil, datum)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	encoder := json.NewEncoder(&b)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(b.Bytes(), []byte("\n")), nil
+}
+
+func runValidate(args []string, stdin io.Reader, stdout io.Writer) error {
+	var mf messageFlags
+	flags := newFlagSet("validate")
+	mf.register(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	messageType, _, err := mf.load()
+	if err != nil {
+		return err
+	}
+	schema, err := mf.opts.InferSchema(messageType.Descriptor())
+	if err != nil {
+		return fmt.Errorf("infer schema: %w", err)
+	}
+	schemaJSON, err := json.Marshal(schema)
+	if err != nil {
+		return fmt.Errorf("marshal schema: %w", err)
+	}
+	codec, err := goavro.NewCodec(string(schemaJSON))
+	if err != nil {
+		return fmt.Errorf("new codec: %w", err)
+	}
+	return forEachInput(flags.Args(), stdin, func(name string, r io.Reader) error {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		reader, err := goavro.NewOCFReader(bytes.NewReader(data))
+		if err != nil {
+			return err
+		}
+		if reader.Codec().CanonicalSchema() != codec.CanonicalSchema() {
+			return fmt.Errorf("schema does not match the schema of '%s'", messageType.Descriptor().FullName())
+		}
+		var count int
+		if err := unmarshalAll(mf.opts, bytes.NewReader(data), messageType, func(proto.Message) error {
+			count++
+			return nil
+		}); err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(stdout, "%s: %d records\n", name, count)
+		return err
+	})
+}
+
+// forEachInput calls fn with each of the input files, or with stdin when there are no input files.
+func forEachInput(files []string, stdin io.Reader, fn func(name string, r io.Reader) error) error {
+	if len(files) == 0 {
+		return fn("stdin", stdin)
+	}
+	for _, file := range files {
+		if err := forFile(file, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func forFile(file string, fn func(name string, r io.Reader) error) error {
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if err := fn(file, f); err != nil {
+		return fmt.Errorf("%s: %w", file, err)
+	}
+	return nil
+}
+
+// withOutput calls fn with the output file, or with stdout when there is no output file.
+func withOutput(out string, stdout io.Writer, fn func(io.Writer) error) error {
+	if out == "" {
+		return fn(stdout)
+	}
+	f, err := os.Create(out)
+	if err != nil {
+		return err
+	}
+	if err := fn(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
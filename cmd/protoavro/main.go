@@ -0,0 +1,64 @@
+// Command protoavro converts, inspects and validates Avro Object Container Files of protobuf messages.
+//
+// Message types are loaded from a binary FileDescriptorSet with -descriptor_set, or built from .proto
+// files with -proto and -proto_path by running protoc.
+//
+// Usage:
+//
+//	protoavro schema -descriptor_set=set.binpb -message=pkg.Message
+//	protoavro encode -descriptor_set=set.binpb -message=pkg.Message -input_format=json [-out=file.avro] [files...]
+//	protoavro decode -descriptor_set=set.binpb -message=pkg.Message -output_format=json [-out=file] [files...]
+//	protoavro cat [files...]
+//	protoavro head -n=10 [files...]
+//	protoavro validate -descriptor_set=set.binpb -message=pkg.Message [files...]
+//
+// Input is read from the files, or from stdin when no files are given.
+// Output is written to stdout, or to the file given with -out.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "protoavro: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+const usage = `usage: protoavro <command> [flags] [files...]
+
+commands:
+  schema    print the Avro schema inferred for a message type
+  encode    convert protobuf messages to an Avro Object Container File
+  decode    convert an Avro Object Container File to protobuf messages
+  cat       print the records of Avro Object Container Files as JSON
+  head      print the first records of Avro Object Container Files as JSON
+  validate  check that Avro Object Container Files match a message type`
+
+type command func(args []string, stdin io.Reader, stdout io.Writer) error
+
+func run(args []string, stdin io.Reader, stdout io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing command\n%s", usage)
+	}
+	commands := map[string]command{
+		"schema":   runSchema,
+		"encode":   runEncode,
+		"decode":   runDecode,
+		"cat":      runCat,
+		"head":     runHead,
+		"validate": runValidate,
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		return fmt.Errorf("unknown command '%s'\n%s", args[0], usage)
+	}
+	if err := cmd(args[1:], stdin, stdout); err != nil {
+		return fmt.Errorf("%s: %w", args[0], err)
+	}
+	return nil
+}
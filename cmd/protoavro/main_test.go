@@ -0,0 +1,236 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.einride.tech/protobuf-avro/encoding/protoavro"
+	examplev1 "go.einride.tech/protobuf-avro/internal/examples/proto/gen/einride/avro/example/v1"
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"gotest.tools/v3/assert"
+)
+
+// writeDescriptorSet writes a descriptor set of the example files to a temporary file.
+func writeDescriptorSet(t *testing.T) string {
+	t.Helper()
+	var set descriptorpb.FileDescriptorSet
+	for _, file := range []protoreflect.FileDescriptor{
+		timestamppb.File_google_protobuf_timestamp_proto,
+		wrapperspb.File_google_protobuf_wrappers_proto,
+		examplev1.File_einride_avro_example_v1_example_list_proto,
+		examplev1.File_einride_avro_example_v1_example_timestamp_proto,
+	} {
+		set.File = append(set.File, protodesc.ToFileDescriptorProto(file))
+	}
+	data, err := proto.Marshal(&set)
+	assert.NilError(t, err)
+	path := filepath.Join(t.TempDir(), "descriptor_set.binpb")
+	assert.NilError(t, os.WriteFile(path, data, 0o600))
+	return path
+}
+
+func runCommand(t *testing.T, stdin []byte, args ...string) (string, error) {
+	t.Helper()
+	var stdout bytes.Buffer
+	err := run(args, bytes.NewReader(stdin), &stdout)
+	return stdout.String(), err
+}
+
+func exampleLists() []*examplev1.ExampleList {
+	return []*examplev1.ExampleList{
+		{Int64List: []int64{1, 2}, StringList: []string{"a"}},
+		{
+			EnumList:       []examplev1.ExampleList_Enum{examplev1.ExampleList_ENUM_VALUE1},
+			NestedList:     []*examplev1.ExampleList_Nested{{StringList: []string{"b"}}},
+			FloatValueList: []*wrapperspb.FloatValue{wrapperspb.Float(1.5)},
+		},
+	}
+}
+
+func TestSchema(t *testing.T) {
+	descriptorSet := writeDescriptorSet(t)
+	out, err := runCommand(
+		t, nil, "schema", "-descriptor_set", descriptorSet, "-message", "einride.avro.example.v1.ExampleTimestamp",
+	)
+	assert.NilError(t, err)
+	expected, err := protoavro.InferSchema((&examplev1.ExampleTimestamp{}).ProtoReflect().Descriptor())
+	assert.NilError(t, err)
+	expectedJSON, err := json.MarshalIndent(expected, "", "  ")
+	assert.NilError(t, err)
+	assert.Equal(t, string(expectedJSON)+"\n", out)
+}
+
+func TestEncodeDecode(t *testing.T) {
+	descriptorSet := writeDescriptorSet(t)
+	message := "einride.avro.example.v1.ExampleList"
+	var jsonInput, delimitedInput bytes.Buffer
+	for _, msg := range exampleLists() {
+		data, err := protojson.Marshal(msg)
+		assert.NilError(t, err)
+		jsonInput.Write(append(data, '\n'))
+		_, err = protodelim.MarshalTo(&delimitedInput, msg)
+		assert.NilError(t, err)
+	}
+	for _, tt := range []struct {
+		name  string
+		input []byte
+	}{
+		{name: "json", input: jsonInput.Bytes()},
+		{name: "delimited", input: delimitedInput.Bytes()},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := runCommand(
+				t, tt.input, "encode", "-descriptor_set", descriptorSet, "-message", message, "-input_format", tt.name,
+			)
+			assert.NilError(t, err)
+
+			out, err := runCommand(
+				t, []byte(encoded), "validate", "-descriptor_set", descriptorSet, "-message", message,
+			)
+			assert.NilError(t, err)
+			assert.Equal(t, "stdin: 2 records\n", out)
+
+			for _, format := range []string{"json", "delimited"} {
+				decoded, err := runCommand(
+					t,
+					[]byte(encoded),
+					"decode", "-descriptor_set", descriptorSet, "-message", message, "-output_format", format,
+				)
+				assert.NilError(t, err)
+				r := strings.NewReader(decoded)
+				for _, expected := range exampleLists() {
+					var actual examplev1.ExampleList
+					if format == "json" {
+						line, err := readLine(r)
+						assert.NilError(t, err)
+						assert.NilError(t, protojson.Unmarshal(line, &actual))
+					} else {
+						assert.NilError(t, protodelim.UnmarshalFrom(r, &actual))
+					}
+					assert.Assert(t, proto.Equal(expected, &actual), "%v != %v", expected, &actual)
+				}
+			}
+		})
+	}
+}
+
+func readLine(r *strings.Reader) ([]byte, error) {
+	var line []byte
+	for {
+		b, err := r.ReadByte()
+		if err != nil || b == '\n' {
+			return line, err
+		}
+		line = append(line, b)
+	}
+}
+
+func TestEncode_Text(t *testing.T) {
+	descriptorSet := writeDescriptorSet(t)
+	input := filepath.Join(t.TempDir(), "input.txtpb")
+	assert.NilError(t, os.WriteFile(input, []byte(`timestamp { seconds: 1 }`), 0o600))
+	output := filepath.Join(t.TempDir(), "output.avro")
+	_, err := runCommand(
+		t,
+		nil,
+		"encode",
+		"-descriptor_set", descriptorSet,
+		"-message", "einride.avro.example.v1.ExampleTimestamp",
+		"-input_format", "text",
+		"-out", output,
+		input,
+	)
+	assert.NilError(t, err)
+	out, err := runCommand(t, nil, "cat", output)
+	assert.NilError(t, err)
+	assert.Equal(
+		t,
+		`{"einride.avro.example.v1.ExampleTimestamp":{"timestamp":{"long.timestamp-micros":1000000}}}`+"\n",
+		out,
+	)
+}
+
+func TestHead(t *testing.T) {
+	var b bytes.Buffer
+	marshaler, err := protoavro.NewMarshaler((&examplev1.ExampleList{}).ProtoReflect().Descriptor(), &b)
+	assert.NilError(t, err)
+	for _, msg := range exampleLists() {
+		assert.NilError(t, marshaler.Marshal(msg))
+	}
+	out, err := runCommand(t, b.Bytes(), "head", "-n", "1")
+	assert.NilError(t, err)
+	assert.Equal(t, 1, strings.Count(out, "\n"))
+	all, err := runCommand(t, b.Bytes(), "cat")
+	assert.NilError(t, err)
+	assert.Equal(t, 2, strings.Count(all, "\n"))
+	assert.Assert(t, strings.HasPrefix(all, out))
+}
+
+func TestValidate_Mismatch(t *testing.T) {
+	descriptorSet := writeDescriptorSet(t)
+	var b bytes.Buffer
+	marshaler, err := protoavro.NewMarshaler((&examplev1.ExampleList{}).ProtoReflect().Descriptor(), &b)
+	assert.NilError(t, err)
+	assert.NilError(t, marshaler.Marshal(exampleLists()[0]))
+	_, err = runCommand(
+		t, b.Bytes(), "validate", "-descriptor_set", descriptorSet, "-message", "einride.avro.example.v1.ExampleTimestamp",
+	)
+	assert.ErrorContains(t, err, "schema does not match")
+}
+
+func TestRun_Errors(t *testing.T) {
+	descriptorSet := writeDescriptorSet(t)
+	for _, tt := range []struct {
+		name          string
+		args          []string
+		errorContains string
+	}{
+		{name: "no command", errorContains: "missing command"},
+		{name: "unknown command", args: []string{"convert"}, errorContains: "unknown command 'convert'"},
+		{name: "no descriptors", args: []string{"schema", "-message", "a.B"}, errorContains: "missing -descriptor_set"},
+		{
+			name:          "no message",
+			args:          []string{"schema", "-descriptor_set", descriptorSet},
+			errorContains: "missing -message",
+		},
+		{
+			name:          "unknown message",
+			args:          []string{"schema", "-descriptor_set", descriptorSet, "-message", "a.B"},
+			errorContains: "find message 'a.B'",
+		},
+		{
+			name: "invalid input format",
+			args: []string{
+				"encode", "-descriptor_set", descriptorSet,
+				"-message", "einride.avro.example.v1.ExampleList", "-input_format", "yaml",
+			},
+			errorContains: "invalid input format 'yaml'",
+		},
+		{
+			name: "invalid output format",
+			args: []string{
+				"decode", "-descriptor_set", descriptorSet,
+				"-message", "einride.avro.example.v1.ExampleList", "-output_format", "text",
+			},
+			errorContains: "invalid output format 'text'",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := runCommand(t, nil, tt.args...)
+			assert.ErrorContains(t, err, tt.errorContains)
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"go.einride.tech/protobuf-avro/encoding/protoavro"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// stringsFlag is a flag that can be repeated.
+type stringsFlag []string
+
+func (s *stringsFlag) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringsFlag) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
+// messageFlags are the flags of commands that work with a message type.
+type messageFlags struct {
+	descriptorSet string
+	protoFiles    stringsFlag
+	protoPaths    stringsFlag
+	protoc        string
+	message       string
+	opts          protoavro.SchemaOptions
+}
+
+func (f *messageFlags) register(flags *flag.FlagSet) {
+	flags.StringVar(&f.descriptorSet, "descriptor_set", "", "binary FileDescriptorSet containing the message type")
+	flags.Var(&f.protoFiles, "proto", ".proto file containing the message type, may be repeated")
+	flags.Var(&f.protoPaths, "proto_path", "directory in which to search for imports, may be repeated")
+	flags.StringVar(&f.protoc, "protoc", "protoc", "protoc binary used to build descriptors from .proto files")
+	flags.StringVar(&f.message, "message", "", "full name of the message type")
+	flags.BoolVar(&f.opts.OmitRootElement, "omit_root_element", false, "do not make the root record nullable")
+	flags.BoolVar(&f.opts.OmitNullArray, "omit_null_array", false, "do not make arrays nullable")
+	flags.BoolVar(&f.opts.FieldIDs, "field_ids", false, "annotate fields with Iceberg field IDs")
+	flags.BoolVar(&f.opts.PreserveUnknownFields, "preserve_unknown_fields", false, "add an _unknown field with unknown fields")
+	flags.BoolVar(&f.opts.ProtoMetadata, "proto_metadata", false, "annotate schemas with protobuf definitions")
+}
+
+// load returns the message type selected by the flags, and the types it was resolved from.
+func (f *messageFlags) load() (protoreflect.MessageType, *dynamicpb.Types, error) {
+	if f.message == "" {
+		return nil, nil, errors.New("missing -message")
+	}
+	set, err := f.loadDescriptorSet()
+	if err != nil {
+		return nil, nil, err
+	}
+	files, err := protodesc.NewFiles(set)
+	if err != nil {
+		return nil, nil, fmt.Errorf("new files: %w", err)
+	}
+	types := dynamicpb.NewTypes(files)
+	messageType, err := types.FindMessageByName(protoreflect.FullName(f.message))
+	if err != nil {
+		return nil, nil, fmt.Errorf("find message '%s': %w", f.message, err)
+	}
+	// Any values are resolved against the loaded files, not the global registry
+	f.opts.AnyResolver = types
+	return messageType, types, nil
+}
+
+func (f *messageFlags) loadDescriptorSet() (*descriptorpb.FileDescriptorSet, error) {
+	var data []byte
+	switch {
+	case f.descriptorSet != "" && len(f.protoFiles) > 0:
+		return nil, errors.New("only one of -descriptor_set and -proto may be set")
+	case f.descriptorSet != "":
+		var err error
+		if data, err = os.ReadFile(f.descriptorSet); err != nil {
+			return nil, err
+		}
+	case len(f.protoFiles) > 0:
+		var err error
+		if data, err = f.runProtoc(); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errors.New("missing -descriptor_set or -proto")
+	}
+	var set descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("unmarshal descriptor set: %w", err)
+	}
+	return &set, nil
+}
+
+// runProtoc builds a descriptor set, including imports, from the .proto files.
+func (f *messageFlags) runProtoc() ([]byte, error) {
+	dir, err := os.MkdirTemp("", "protoavro")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	out := filepath.Join(dir, "descriptor_set.binpb")
+	args := []string{"--include_imports", "--include_source_info", "--descriptor_set_out=" + out}
+	for _, path := range f.protoPaths {
+		args = append(args, "--proto_path="+path)
+	}
+	args = append(args, f.protoFiles...)
+	cmd := exec.Command(f.protoc, args...)
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("run %s: %w", f.protoc, err)
+	}
+	return os.ReadFile(out)
+}
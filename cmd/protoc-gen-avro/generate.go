@@ -0,0 +1,120 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"go.einride.tech/protobuf-avro/avro"
+	"go.einride.tech/protobuf-avro/encoding/protoavro"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const (
+	formatAVSC = "avsc"
+	formatAVDL = "avdl"
+
+	docComments = "comments"
+	docNone     = "none"
+)
+
+type config struct {
+	format string
+	doc    string
+	opts   protoavro.SchemaOptions
+}
+
+func generate(plugin *protogen.Plugin, cfg config) error {
+	switch cfg.doc {
+	case docComments:
+	case docNone:
+		cfg.opts.DocCallback = func(protoreflect.Descriptor) string { return "" }
+	default:
+		return fmt.Errorf("invalid doc parameter '%s'", cfg.doc)
+	}
+	generateFile := generateAVSC
+	switch cfg.format {
+	case formatAVSC:
+	case formatAVDL:
+		generateFile = generateAVDL
+	default:
+		return fmt.Errorf("invalid format parameter '%s'", cfg.format)
+	}
+	for _, file := range plugin.Files {
+		if !file.Generate {
+			continue
+		}
+		if err := generateFile(plugin, file, cfg.opts); err != nil {
+			return fmt.Errorf("%s: %w", file.Desc.Path(), err)
+		}
+	}
+	return nil
+}
+
+// generateAVSC generates one schema file per top-level message of the file,
+// next to the proto file.
+func generateAVSC(plugin *protogen.Plugin, file *protogen.File, opts protoavro.SchemaOptions) error {
+	for _, message := range file.Messages {
+		schema, err := inferSchema(message, opts)
+		if err != nil {
+			return err
+		}
+		data, err := json.MarshalIndent(schema, "", "  ")
+		if err != nil {
+			return fmt.Errorf("%s: marshal schema: %w", message.Desc.FullName(), err)
+		}
+		filename := path.Join(path.Dir(file.Desc.Path()), string(message.Desc.Name())+".avsc")
+		g := plugin.NewGeneratedFile(filename, "")
+		if _, err := g.Write(append(data, '\n')); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// generateAVDL generates one IDL protocol per proto file, next to the proto file,
+// declaring the schemas of its top-level messages.
+func generateAVDL(plugin *protogen.Plugin, file *protogen.File, opts protoavro.SchemaOptions) error {
+	protocol := avro.Protocol{
+		Name:      protocolName(file),
+		Namespace: string(file.Desc.Package()),
+	}
+	for _, message := range file.Messages {
+		schema, err := inferSchema(message, opts)
+		if err != nil {
+			return err
+		}
+		protocol.Types = append(protocol.Types, schema)
+	}
+	data, err := protocol.MarshalIDL()
+	if err != nil {
+		return fmt.Errorf("marshal idl: %w", err)
+	}
+	g := plugin.NewGeneratedFile(strings.TrimSuffix(file.Desc.Path(), ".proto")+".avdl", "")
+	_, err = g.Write(data)
+	return err
+}
+
+func inferSchema(message *protogen.Message, opts protoavro.SchemaOptions) (avro.Schema, error) {
+	schema, err := opts.InferSchema(message.Desc)
+	if err != nil {
+		return nil, fmt.Errorf("%s: infer schema: %w", message.Desc.FullName(), err)
+	}
+	if err := avro.Validate(schema); err != nil {
+		return nil, fmt.Errorf("%s: %w", message.Desc.FullName(), err)
+	}
+	return schema, nil
+}
+
+// protocolName returns the name of the IDL protocol of a file,
+// which is the upper camel case of the file name.
+func protocolName(file *protogen.File) string {
+	base := strings.TrimSuffix(path.Base(file.Desc.Path()), ".proto")
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(base, func(r rune) bool { return r == '_' || r == '-' || r == '.' }) {
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"go.einride.tech/protobuf-avro/avro"
+	"go.einride.tech/protobuf-avro/encoding/protoavro"
+	examplev1 "go.einride.tech/protobuf-avro/internal/examples/proto/gen/einride/avro/example/v1"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+	"gotest.tools/v3/assert"
+)
+
+func runPlugin(t *testing.T, fd protoreflect.FileDescriptor, cfg config) map[string]string {
+	t.Helper()
+	file := protodesc.ToFileDescriptorProto(fd)
+	plugin, err := protogen.Options{}.New(&pluginpb.CodeGeneratorRequest{
+		FileToGenerate: []string{file.GetName()},
+		ProtoFile:      []*descriptorpb.FileDescriptorProto{file},
+	})
+	assert.NilError(t, err)
+	assert.NilError(t, generate(plugin, cfg))
+	response := plugin.Response()
+	assert.Equal(t, "", response.GetError())
+	files := make(map[string]string, len(response.GetFile()))
+	for _, f := range response.GetFile() {
+		files[f.GetName()] = f.GetContent()
+	}
+	return files
+}
+
+func TestGenerate_AVSC(t *testing.T) {
+	files := runPlugin(t, examplev1.File_einride_avro_example_v1_example_enum_proto, config{format: formatAVSC, doc: docComments})
+	assert.Equal(t, 1, len(files))
+	content, ok := files["einride/avro/example/v1/ExampleEnum.avsc"]
+	assert.Assert(t, ok)
+	var schema interface{}
+	assert.NilError(t, json.Unmarshal([]byte(content), &schema))
+	expected, err := json.Marshal(avro.Nullable(avro.Record{
+		Type:      avro.RecordType,
+		Name:      "ExampleEnum",
+		Namespace: "einride.avro.example.v1",
+		Fields: []avro.Field{
+			{
+				Name: "enum_value",
+				Type: avro.Nullable(avro.Enum{
+					Type:      avro.EnumType,
+					Name:      "Enum",
+					Namespace: "einride.avro.example.v1.ExampleEnum",
+					Symbols:   []string{"ENUM_UNSPECIFIED", "ENUM_VALUE1", "ENUM_VALUE2", "ENUM_VALUE3"},
+					Default:   "ENUM_UNSPECIFIED",
+				}),
+				Default: avro.NullDefault,
+				Props:   map[string]interface{}{"proto.number": 1},
+			},
+		},
+	}))
+	assert.NilError(t, err)
+	var expectedSchema interface{}
+	assert.NilError(t, json.Unmarshal(expected, &expectedSchema))
+	assert.DeepEqual(t, expectedSchema, schema)
+}
+
+func TestGenerate_AVDL(t *testing.T) {
+	files := runPlugin(t, examplev1.File_einride_avro_example_v1_example_enum_proto, config{format: formatAVDL, doc: docNone})
+	assert.DeepEqual(t, map[string]string{
+		"einride/avro/example/v1/example_enum.avdl": `@namespace("einride.avro.example.v1")
+protocol ExampleEnum {
+  @namespace("einride.avro.example.v1.ExampleEnum")
+  enum Enum {
+    ENUM_UNSPECIFIED,
+    ENUM_VALUE1,
+    ENUM_VALUE2,
+    ENUM_VALUE3
+  } = ENUM_UNSPECIFIED;
+
+  record ExampleEnum {
+    union { null, einride.avro.example.v1.ExampleEnum.Enum } @proto.number(1) enum_value = null;
+  }
+}
+`,
+	}, files)
+}
+
+func TestGenerate_Editions(t *testing.T) {
+	files := runPlugin(t, examplev1.File_einride_avro_example_v1_example_editions_proto, config{
+		format: formatAVDL,
+		doc:    docNone,
+		opts:   protoavro.SchemaOptions{OmitRootElement: true},
+	})
+	content := files["einride/avro/example/v1/example_editions.avdl"]
+	assert.Assert(t, strings.Contains(content, "    union { null, string } @proto.number(1) explicit_string = null;\n"), content)
+	assert.Assert(t, strings.Contains(content, "    string @proto.number(3) required_string;\n"), content)
+	assert.Assert(t, strings.Contains(content, "    union { int, null } @proto.number(4) default_int32 = 7;\n"), content)
+}
+
+func TestGenerate_InvalidParameter(t *testing.T) {
+	plugin, err := protogen.Options{}.New(&pluginpb.CodeGeneratorRequest{})
+	assert.NilError(t, err)
+	assert.ErrorContains(t, generate(plugin, config{format: "json", doc: docNone}), "invalid format parameter 'json'")
+	assert.ErrorContains(t, generate(plugin, config{format: formatAVSC, doc: "trailing"}), "invalid doc parameter 'trailing'")
+}
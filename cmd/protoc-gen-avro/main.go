@@ -0,0 +1,42 @@
+// Command protoc-gen-avro is a protoc plugin that generates Avro schemas from protobuf messages.
+//
+// By default, one .avsc schema file is generated per top-level message. With the parameter
+// format=avdl, one Avro IDL protocol file is generated per proto file instead.
+//
+// Parameters:
+//
+//	format=avsc|avdl              output format, defaults to avsc
+//	omit_root_element=true        do not make the root record nullable
+//	omit_null_array=true          do not make arrays and their elements nullable
+//	field_ids=true                annotate fields with Iceberg field IDs
+//	preserve_unknown_fields=true  add an _unknown field to records for unknown fields
+//	proto_metadata=true           annotate fields, records and enums with their protobuf definitions
+//	doc=comments|none             source of record and field docs, defaults to leading comments
+package main
+
+import (
+	"flag"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+func main() {
+	var flags flag.FlagSet
+	var cfg config
+	flags.StringVar(&cfg.format, "format", formatAVSC, "output format, avsc or avdl")
+	flags.BoolVar(&cfg.opts.OmitRootElement, "omit_root_element", false, "do not make the root record nullable")
+	flags.BoolVar(&cfg.opts.OmitNullArray, "omit_null_array", false, "do not make arrays nullable")
+	flags.BoolVar(&cfg.opts.FieldIDs, "field_ids", false, "annotate fields with Iceberg field IDs")
+	flags.BoolVar(&cfg.opts.PreserveUnknownFields, "preserve_unknown_fields", false, "add an _unknown field with unknown fields")
+	flags.BoolVar(&cfg.opts.ProtoMetadata, "proto_metadata", false, "annotate schemas with protobuf definitions")
+	flags.StringVar(&cfg.doc, "doc", docComments, "source of docs, comments or none")
+	protogen.Options{ParamFunc: flags.Set}.Run(func(plugin *protogen.Plugin) error {
+		plugin.SupportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL |
+			pluginpb.CodeGeneratorResponse_FEATURE_SUPPORTS_EDITIONS)
+		plugin.SupportedEditionsMinimum = descriptorpb.Edition_EDITION_PROTO2
+		plugin.SupportedEditionsMaximum = descriptorpb.Edition_EDITION_2023
+		return generate(plugin, cfg)
+	})
+}
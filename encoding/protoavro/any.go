@@ -0,0 +1,115 @@
+package protoavro
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// UnknownAnyPolicy determines how google.protobuf.Any values are handled
+// when the type of their payload cannot be resolved.
+type UnknownAnyPolicy int
+
+const (
+	// UnknownAnyFail fails encoding and decoding of Any values with unresolvable types.
+	UnknownAnyFail UnknownAnyPolicy = iota
+	// UnknownAnyPreserve keeps the type URL and serialized value of Any values with unresolvable types.
+	// In JSON strings, they are encoded as an object with the fields "@type" and "@value",
+	// where "@value" holds the base64 encoded payload. Preserved values are decoded by readers
+	// that can resolve their types, whatever their policy.
+	UnknownAnyPreserve
+)
+
+// rawAnyValue is the JSON field of the serialized value of preserved Any values.
+// It is not used by protojson, which tells preserved values apart from resolved values.
+const rawAnyValue = "@value"
+
+// rawAny is the JSON encoding of an Any value with an unresolvable type.
+type rawAny struct {
+	TypeURL string `json:"@type"`
+	Value   []byte `json:"@value"`
+}
+
+// anyResolver adapts a protoregistry.MessageTypeResolver to the resolver used by protojson.
+type anyResolver struct {
+	protoregistry.MessageTypeResolver
+}
+
+func (r anyResolver) FindExtensionByName(name protoreflect.FullName) (protoreflect.ExtensionType, error) {
+	if ext, ok := r.MessageTypeResolver.(protoregistry.ExtensionTypeResolver); ok {
+		return ext.FindExtensionByName(name)
+	}
+	return nil, protoregistry.NotFound
+}
+
+func (r anyResolver) FindExtensionByNumber(
+	message protoreflect.FullName,
+	field protoreflect.FieldNumber,
+) (protoreflect.ExtensionType, error) {
+	if ext, ok := r.MessageTypeResolver.(protoregistry.ExtensionTypeResolver); ok {
+		return ext.FindExtensionByNumber(message, field)
+	}
+	return nil, protoregistry.NotFound
+}
+
+func (o SchemaOptions) anyResolver() anyResolver {
+	if o.AnyResolver == nil {
+		return anyResolver{MessageTypeResolver: protoregistry.GlobalTypes}
+	}
+	return anyResolver{MessageTypeResolver: o.AnyResolver}
+}
+
+// isUnknownAny reports whether the type of a should be preserved instead of resolved.
+func (o SchemaOptions) isUnknownAny(typeURL string) bool {
+	if o.UnknownAny != UnknownAnyPreserve {
+		return false
+	}
+	_, err := o.anyResolver().FindMessageByURL(typeURL)
+	return errors.Is(err, protoregistry.NotFound)
+}
+
+func (o SchemaOptions) marshalAnyJSON(a *anypb.Any) ([]byte, error) {
+	if o.isUnknownAny(a.GetTypeUrl()) {
+		return json.Marshal(rawAny{TypeURL: a.GetTypeUrl(), Value: a.GetValue()})
+	}
+	return protojson.MarshalOptions{Resolver: o.anyResolver()}.Marshal(a)
+}
+
+func (o SchemaOptions) unmarshalAnyJSON(data []byte) (*anypb.Any, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err == nil && fields[rawAnyValue] != nil {
+		return o.unmarshalRawAnyJSON(data)
+	}
+	var a anypb.Any
+	if err := (protojson.UnmarshalOptions{Resolver: o.anyResolver()}).Unmarshal(data, &a); err != nil {
+		return nil, fmt.Errorf("unmarshal: %w", err)
+	}
+	return &a, nil
+}
+
+// unmarshalRawAnyJSON returns the preserved Any value in data. Values of types known to the
+// resolver are checked by unmarshaling them, and values of other types are only returned with
+// UnknownAnyPreserve.
+func (o SchemaOptions) unmarshalRawAnyJSON(data []byte) (*anypb.Any, error) {
+	var raw rawAny
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("unmarshal: %w", err)
+	}
+	messageType, err := o.anyResolver().FindMessageByURL(raw.TypeURL)
+	switch {
+	case err == nil:
+		message := messageType.New().Interface()
+		if err := (proto.UnmarshalOptions{Resolver: o.anyResolver()}).Unmarshal(raw.Value, message); err != nil {
+			return nil, fmt.Errorf("unmarshal %s: %w", raw.TypeURL, err)
+		}
+	case !errors.Is(err, protoregistry.NotFound) || o.UnknownAny != UnknownAnyPreserve:
+		return nil, fmt.Errorf("find type %s: %w", raw.TypeURL, err)
+	}
+	return &anypb.Any{TypeUrl: raw.TypeURL, Value: raw.Value}, nil
+}
@@ -0,0 +1,259 @@
+// Package bigquery provides BigQuery table schemas for protobuf messages written as Avro.
+package bigquery
+
+import (
+	"fmt"
+	"strings"
+
+	"go.einride.tech/protobuf-avro/avro"
+	"go.einride.tech/protobuf-avro/encoding/protoavro"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// FieldType is the type of a BigQuery field.
+type FieldType string
+
+const (
+	StringFieldType    FieldType = "STRING"
+	BytesFieldType     FieldType = "BYTES"
+	IntegerFieldType   FieldType = "INTEGER"
+	FloatFieldType     FieldType = "FLOAT"
+	BooleanFieldType   FieldType = "BOOLEAN"
+	TimestampFieldType FieldType = "TIMESTAMP"
+	DateFieldType      FieldType = "DATE"
+	TimeFieldType      FieldType = "TIME"
+	DateTimeFieldType  FieldType = "DATETIME"
+	RecordFieldType    FieldType = "RECORD"
+)
+
+// Mode is the mode of a BigQuery field.
+type Mode string
+
+const (
+	NullableMode Mode = "NULLABLE"
+	RequiredMode Mode = "REQUIRED"
+	RepeatedMode Mode = "REPEATED"
+)
+
+// TableFieldSchema describes a field of a BigQuery table.
+// JSON encoding of a TableFieldSchema value matches the TableFieldSchema resource of the BigQuery API,
+// and a list of them matches the schema files accepted by the bq tool.
+type TableFieldSchema struct {
+	Name        string             `json:"name"`
+	Type        FieldType          `json:"type"`
+	Mode        Mode               `json:"mode"`
+	Description string             `json:"description,omitempty"`
+	Fields      []TableFieldSchema `json:"fields,omitempty"`
+}
+
+// InferTableSchema returns the schema of the BigQuery table created by loading Avro files,
+// written with the SchemaOptions, of the protobuf message descriptor with use_avro_logical_types.
+func InferTableSchema(desc protoreflect.MessageDescriptor, opts protoavro.SchemaOptions) ([]TableFieldSchema, error) {
+	schema, err := opts.InferSchema(desc)
+	if err != nil {
+		return nil, fmt.Errorf("infer schema: %w", err)
+	}
+	c := converter{named: make(map[string]avro.Schema), inProgress: make(map[string]bool)}
+	fieldType, fields, err := c.convertType(schema)
+	if err != nil {
+		return nil, err
+	}
+	if fieldType != RecordFieldType {
+		return nil, fmt.Errorf("message '%s' is not mapped to a record", desc.FullName())
+	}
+	return fields, nil
+}
+
+// converter converts Avro schemas to BigQuery schemas according to the Avro conversion rules of BigQuery.
+// See: https://cloud.google.com/bigquery/docs/loading-data-cloud-storage-avro#avro_conversions
+type converter struct {
+	// named maps the full names of named types to their schemas,
+	// for resolving references.
+	named map[string]avro.Schema
+	// inProgress holds the full names of the records being converted,
+	// for detecting recursive types.
+	inProgress map[string]bool
+}
+
+func (c converter) convertField(field avro.Field) (TableFieldSchema, error) {
+	mode := RequiredMode
+	schema := field.Type
+	if union, ok := schema.(avro.Union); ok {
+		if branch, ok := nullableBranch(union); ok {
+			mode = NullableMode
+			schema = branch
+		}
+	}
+	if array, ok := schema.(avro.Array); ok {
+		// BigQuery arrays can not be null, and neither can their elements
+		mode = RepeatedMode
+		schema = array.Items
+		if union, ok := schema.(avro.Union); ok {
+			if branch, ok := nullableBranch(union); ok {
+				schema = branch
+			}
+		}
+	}
+	if m, ok := schema.(avro.Map); ok {
+		if mode == RepeatedMode {
+			return TableFieldSchema{}, fmt.Errorf("field '%s': arrays of maps are not supported by BigQuery", field.Name)
+		}
+		// maps are loaded as repeated records of keys and values
+		value, err := c.convertField(avro.Field{Name: "value", Type: m.Values})
+		if err != nil {
+			return TableFieldSchema{}, fmt.Errorf("field '%s': %w", field.Name, err)
+		}
+		return TableFieldSchema{
+			Name:        field.Name,
+			Type:        RecordFieldType,
+			Mode:        RepeatedMode,
+			Description: field.Doc,
+			Fields: []TableFieldSchema{
+				{Name: "key", Type: StringFieldType, Mode: RequiredMode},
+				value,
+			},
+		}, nil
+	}
+	fieldType, fields, err := c.convertType(schema)
+	if err != nil {
+		return TableFieldSchema{}, fmt.Errorf("field '%s': %w", field.Name, err)
+	}
+	return TableFieldSchema{
+		Name:        field.Name,
+		Type:        fieldType,
+		Mode:        mode,
+		Description: field.Doc,
+		Fields:      fields,
+	}, nil
+}
+
+// convertType returns the BigQuery type of the schema, and the fields of the type when it is a record.
+func (c converter) convertType(schema avro.Schema) (FieldType, []TableFieldSchema, error) {
+	switch s := schema.(type) {
+	case avro.Reference:
+		if c.inProgress[string(s)] {
+			return "", nil, fmt.Errorf("recursive type '%s' is not supported by BigQuery", s)
+		}
+		named, ok := c.named[string(s)]
+		if !ok {
+			return "", nil, fmt.Errorf("unknown type '%s'", s)
+		}
+		return c.convertType(named)
+	case avro.Primitive:
+		return convertPrimitive(s)
+	case avro.Enum:
+		c.named[fullName(s.Namespace, s.Name)] = s
+		return StringFieldType, nil, nil
+	case avro.Fixed:
+		c.named[fullName(s.Namespace, s.Name)] = s
+		return BytesFieldType, nil, nil
+	case avro.Record:
+		full := fullName(s.Namespace, s.Name)
+		c.named[full] = s
+		c.inProgress[full] = true
+		defer delete(c.inProgress, full)
+		fields := make([]TableFieldSchema, 0, len(s.Fields))
+		for _, field := range s.Fields {
+			f, err := c.convertField(field)
+			if err != nil {
+				return "", nil, err
+			}
+			fields = append(fields, f)
+		}
+		return RecordFieldType, fields, nil
+	case avro.Map:
+		return "", nil, fmt.Errorf("nested maps are not supported by BigQuery")
+	case avro.Union:
+		if branch, ok := nullableBranch(s); ok {
+			return c.convertType(branch)
+		}
+		// unions of several types are loaded as records with one nullable field per type
+		fields := make([]TableFieldSchema, 0, len(s))
+		for _, branch := range s {
+			if p, ok := branch.(avro.Primitive); ok && p.Type == avro.NullType {
+				continue
+			}
+			fieldType, branchFields, err := c.convertType(branch)
+			if err != nil {
+				return "", nil, err
+			}
+			fields = append(fields, TableFieldSchema{
+				Name:   branchName(branch),
+				Type:   fieldType,
+				Mode:   NullableMode,
+				Fields: branchFields,
+			})
+		}
+		return RecordFieldType, fields, nil
+	case avro.Array:
+		return "", nil, fmt.Errorf("nested arrays are not supported by BigQuery")
+	}
+	return "", nil, fmt.Errorf("unsupported schema %T", schema)
+}
+
+func convertPrimitive(p avro.Primitive) (FieldType, []TableFieldSchema, error) {
+	switch p.LogicalType {
+	case avro.DateLogicalType:
+		return DateFieldType, nil, nil
+	case avro.TimeMicrosLogicalType:
+		return TimeFieldType, nil, nil
+	case avro.TimestampMicrosLogicalType:
+		return TimestampFieldType, nil, nil
+	case avro.DateTimeLogicalType:
+		return DateTimeFieldType, nil, nil
+	}
+	switch p.Type {
+	case avro.BooleanType:
+		return BooleanFieldType, nil, nil
+	case avro.IntType, avro.LongType:
+		return IntegerFieldType, nil, nil
+	case avro.FloatType, avro.DoubleType:
+		return FloatFieldType, nil, nil
+	case avro.StringType:
+		return StringFieldType, nil, nil
+	case avro.BytesType:
+		return BytesFieldType, nil, nil
+	}
+	return "", nil, fmt.Errorf("unsupported type '%s'", p.Type)
+}
+
+// nullableBranch returns the non-null branch of a union of null and one other type.
+func nullableBranch(union avro.Union) (avro.Schema, bool) {
+	if len(union) != 2 {
+		return nil, false
+	}
+	for i, branch := range union {
+		if p, ok := branch.(avro.Primitive); ok && p.Type == avro.NullType {
+			return union[1-i], true
+		}
+	}
+	return nil, false
+}
+
+// branchName returns the name of the field of a union branch.
+func branchName(schema avro.Schema) string {
+	switch s := schema.(type) {
+	case avro.Reference:
+		return string(s)[strings.LastIndex(string(s), ".")+1:]
+	case avro.Record:
+		return s.Name
+	case avro.Enum:
+		return s.Name
+	case avro.Fixed:
+		return s.Name
+	case avro.Primitive:
+		return string(s.Type)
+	case avro.Array:
+		return string(avro.ArrayType)
+	case avro.Map:
+		return string(avro.MapType)
+	}
+	return ""
+}
+
+func fullName(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + "." + name
+}
@@ -0,0 +1,287 @@
+package bigquery
+
+import (
+	"encoding/json"
+	"testing"
+
+	"go.einride.tech/protobuf-avro/encoding/protoavro"
+	avroexamplev1 "go.einride.tech/protobuf-avro/internal/examples/proto/gen/einride/avro/example/v1"
+	examplev1 "go.einride.tech/protobuf-avro/internal/examples/proto/gen/einride/bigquery/example/v1"
+	publicv1 "go.einride.tech/protobuf-avro/internal/examples/proto/gen/einride/bigquery/public/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"gotest.tools/v3/assert"
+)
+
+func TestInferTableSchema(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		name     string
+		msg      proto.Message
+		opts     protoavro.SchemaOptions
+		expected []TableFieldSchema
+	}{
+		{
+			name: "ExampleEnum",
+			msg:  &examplev1.ExampleEnum{},
+			expected: []TableFieldSchema{
+				{Name: "enum_value", Type: StringFieldType, Mode: NullableMode},
+			},
+		},
+		{
+			name: "ExampleEnum int encoding",
+			msg:  &examplev1.ExampleEnum{},
+			opts: protoavro.SchemaOptions{EnumEncoding: protoavro.EnumNumber},
+			expected: []TableFieldSchema{
+				{Name: "enum_value", Type: IntegerFieldType, Mode: NullableMode},
+			},
+		},
+		{
+			name: "ExampleList",
+			msg:  &examplev1.ExampleList{},
+			expected: []TableFieldSchema{
+				{Name: "int64_list", Type: IntegerFieldType, Mode: RepeatedMode},
+				{Name: "string_list", Type: StringFieldType, Mode: RepeatedMode},
+				{Name: "enum_list", Type: StringFieldType, Mode: RepeatedMode},
+				{
+					Name: "nested_list",
+					Type: RecordFieldType,
+					Mode: RepeatedMode,
+					Fields: []TableFieldSchema{
+						{Name: "string_list", Type: StringFieldType, Mode: RepeatedMode},
+					},
+				},
+				{Name: "float_value_list", Type: FloatFieldType, Mode: RepeatedMode},
+			},
+		},
+		{
+			name: "ExampleMap",
+			msg:  &examplev1.ExampleMap{},
+			opts: protoavro.SchemaOptions{
+				FieldMask: &fieldmaskpb.FieldMask{Paths: []string{"string_to_string", "string_to_nested", "int32_to_string"}},
+			},
+			expected: []TableFieldSchema{
+				{
+					Name: "string_to_string",
+					Type: RecordFieldType,
+					Mode: RepeatedMode,
+					Fields: []TableFieldSchema{
+						{Name: "key", Type: StringFieldType, Mode: NullableMode},
+						{Name: "value", Type: StringFieldType, Mode: NullableMode},
+					},
+				},
+				{
+					Name: "string_to_nested",
+					Type: RecordFieldType,
+					Mode: RepeatedMode,
+					Fields: []TableFieldSchema{
+						{Name: "key", Type: StringFieldType, Mode: NullableMode},
+						{
+							Name: "value",
+							Type: RecordFieldType,
+							Mode: NullableMode,
+							Fields: []TableFieldSchema{
+								{
+									Name: "string_to_string",
+									Type: RecordFieldType,
+									Mode: RepeatedMode,
+									Fields: []TableFieldSchema{
+										{Name: "key", Type: StringFieldType, Mode: NullableMode},
+										{Name: "value", Type: StringFieldType, Mode: NullableMode},
+									},
+								},
+							},
+						},
+					},
+				},
+				{
+					Name: "int32_to_string",
+					Type: RecordFieldType,
+					Mode: RepeatedMode,
+					Fields: []TableFieldSchema{
+						{Name: "key", Type: IntegerFieldType, Mode: NullableMode},
+						{Name: "value", Type: StringFieldType, Mode: NullableMode},
+					},
+				},
+			},
+		},
+		{
+			name: "ExampleOneof",
+			msg:  &examplev1.ExampleOneof{},
+			expected: []TableFieldSchema{
+				{
+					Name:        "oneof_empty_message_1",
+					Type:        RecordFieldType,
+					Mode:        NullableMode,
+					Description: "At most one will be set:\n* oneof_empty_message_1\n* oneof_bool_1",
+					Fields:      []TableFieldSchema{},
+				},
+				{
+					Name:        "oneof_bool_1",
+					Type:        BooleanFieldType,
+					Mode:        NullableMode,
+					Description: "At most one will be set:\n* oneof_empty_message_1\n* oneof_bool_1",
+				},
+				{
+					Name:        "oneof_empty_message_2",
+					Type:        RecordFieldType,
+					Mode:        NullableMode,
+					Description: "At most one will be set:\n* oneof_empty_message_2\n* oneof_message",
+					Fields:      []TableFieldSchema{},
+				},
+				{
+					Name:        "oneof_message",
+					Type:        RecordFieldType,
+					Mode:        NullableMode,
+					Description: "At most one will be set:\n* oneof_empty_message_2\n* oneof_message",
+					Fields: []TableFieldSchema{
+						{Name: "string_value", Type: StringFieldType, Mode: NullableMode},
+					},
+				},
+			},
+		},
+		{
+			name: "ExampleWrappers",
+			msg:  &examplev1.ExampleWrappers{},
+			opts: protoavro.SchemaOptions{OmitRootElement: true},
+			expected: []TableFieldSchema{
+				{Name: "float_value", Type: FloatFieldType, Mode: NullableMode},
+				{Name: "double_value", Type: FloatFieldType, Mode: NullableMode},
+				{Name: "string_value", Type: StringFieldType, Mode: NullableMode},
+				{Name: "bytes_value", Type: BytesFieldType, Mode: NullableMode},
+				{Name: "int32_value", Type: IntegerFieldType, Mode: NullableMode},
+				{Name: "int64_value", Type: IntegerFieldType, Mode: NullableMode},
+				{Name: "uint32_value", Type: IntegerFieldType, Mode: NullableMode},
+				{Name: "uint64_value", Type: IntegerFieldType, Mode: NullableMode},
+				{Name: "bool_value", Type: BooleanFieldType, Mode: NullableMode},
+			},
+		},
+		{
+			name: "ExampleProto2",
+			msg:  &avroexamplev1.ExampleProto2{},
+			opts: protoavro.SchemaOptions{
+				FieldMask: &fieldmaskpb.FieldMask{Paths: []string{"required_string", "default_int32"}},
+			},
+			expected: []TableFieldSchema{
+				{Name: "required_string", Type: StringFieldType, Mode: RequiredMode},
+				{Name: "default_int32", Type: IntegerFieldType, Mode: NullableMode},
+			},
+		},
+		{
+			name: "LondonBicycleStation",
+			msg:  &publicv1.LondonBicycleStation{},
+			opts: protoavro.SchemaOptions{
+				DocCallback: func(desc protoreflect.Descriptor) string {
+					return string(desc.Name())
+				},
+				FieldMask: &fieldmaskpb.FieldMask{Paths: []string{"id", "latitude", "install_date"}},
+			},
+			expected: []TableFieldSchema{
+				{Name: "id", Type: IntegerFieldType, Mode: NullableMode, Description: "id"},
+				{Name: "latitude", Type: FloatFieldType, Mode: NullableMode, Description: "latitude"},
+				{Name: "install_date", Type: DateFieldType, Mode: NullableMode, Description: "install_date"},
+			},
+		},
+		{
+			name: "SanFransiscoTransitStopTime",
+			msg:  &publicv1.SanFransiscoTransitStopTime{},
+			opts: protoavro.SchemaOptions{FieldMask: &fieldmaskpb.FieldMask{Paths: []string{"stop_id", "arrival_time"}}},
+			expected: []TableFieldSchema{
+				{Name: "stop_id", Type: IntegerFieldType, Mode: NullableMode},
+				{Name: "arrival_time", Type: TimeFieldType, Mode: NullableMode},
+			},
+		},
+		{
+			name: "HackerNewsStory",
+			msg:  &publicv1.HackerNewsStory{},
+			expected: []TableFieldSchema{
+				{Name: "id", Type: IntegerFieldType, Mode: NullableMode},
+				{Name: "by", Type: StringFieldType, Mode: NullableMode},
+				{Name: "score", Type: IntegerFieldType, Mode: NullableMode},
+				{Name: "time", Type: IntegerFieldType, Mode: NullableMode},
+				{Name: "time_ts", Type: TimestampFieldType, Mode: NullableMode},
+				{Name: "title", Type: StringFieldType, Mode: NullableMode},
+				{Name: "url", Type: StringFieldType, Mode: NullableMode},
+				{Name: "text", Type: StringFieldType, Mode: NullableMode},
+				{Name: "deleted", Type: BooleanFieldType, Mode: NullableMode},
+				{Name: "dead", Type: BooleanFieldType, Mode: NullableMode},
+				{Name: "descendants", Type: IntegerFieldType, Mode: NullableMode},
+				{Name: "author", Type: StringFieldType, Mode: NullableMode},
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := InferTableSchema(tt.msg.ProtoReflect().Descriptor(), tt.opts)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tt.expected, got)
+		})
+	}
+}
+
+func TestInferTableSchema_ProfileBigQuery(t *testing.T) {
+	t.Parallel()
+	got, err := InferTableSchema(
+		(&publicv1.HistoricSevereStorm{}).ProtoReflect().Descriptor(),
+		protoavro.SchemaOptions{
+			Profile:   protoavro.ProfileBigQuery,
+			FieldMask: &fieldmaskpb.FieldMask{Paths: []string{"event_id", "event_begin_time"}},
+		},
+	)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []TableFieldSchema{
+		{Name: "event_id", Type: StringFieldType, Mode: NullableMode},
+		{Name: "event_begin_time", Type: DateTimeFieldType, Mode: NullableMode},
+	}, got)
+	// the structured encoding of google.protobuf.Struct is not used with the profile
+	got, err = InferTableSchema(
+		(&publicv1.WhosOnFirstGeoJson{}).ProtoReflect().Descriptor(),
+		protoavro.SchemaOptions{
+			Profile:       protoavro.ProfileBigQuery,
+			StructuredWKT: true,
+			FieldMask:     &fieldmaskpb.FieldMask{Paths: []string{"body"}},
+		},
+	)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []TableFieldSchema{{Name: "body", Type: StringFieldType, Mode: NullableMode}}, got)
+}
+
+func TestInferTableSchema_StructuredWKT(t *testing.T) {
+	t.Parallel()
+	got, err := InferTableSchema(
+		(&publicv1.WhosOnFirstGeoJson{}).ProtoReflect().Descriptor(),
+		protoavro.SchemaOptions{StructuredWKT: true, FieldMask: &fieldmaskpb.FieldMask{Paths: []string{"body"}}},
+	)
+	// google.protobuf.Value can not be represented in BigQuery
+	assert.ErrorContains(t, err, "field 'body': field 'value': field 'kind': nested arrays are not supported by BigQuery")
+	assert.Assert(t, got == nil)
+}
+
+func TestInferTableSchema_Recursive(t *testing.T) {
+	t.Parallel()
+	_, err := InferTableSchema(
+		(&avroexamplev1.ExampleRecursive{}).ProtoReflect().Descriptor(),
+		protoavro.SchemaOptions{},
+	)
+	assert.ErrorContains(t, err, "recursive type 'einride.avro.example.v1.ExampleRecursive' is not supported by BigQuery")
+}
+
+func TestTableFieldSchema_JSON(t *testing.T) {
+	t.Parallel()
+	got, err := InferTableSchema(
+		(&examplev1.ExampleList{}).ProtoReflect().Descriptor(),
+		protoavro.SchemaOptions{FieldMask: &fieldmaskpb.FieldMask{Paths: []string{"int64_list", "nested_list"}}},
+	)
+	assert.NilError(t, err)
+	data, err := json.Marshal(got)
+	assert.NilError(t, err)
+	assert.Equal(
+		t,
+		`[{"name":"int64_list","type":"INTEGER","mode":"REPEATED"},`+
+			`{"name":"nested_list","type":"RECORD","mode":"REPEATED","fields":`+
+			`[{"name":"string_list","type":"STRING","mode":"REPEATED"}]}]`,
+		string(data),
+	)
+}
@@ -0,0 +1,248 @@
+package protoavro
+
+import (
+	"fmt"
+	"time"
+
+	"go.einride.tech/protobuf-avro/avro"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// EncodedDatum is a protobuf message encoded as an Avro datum.
+// EncodedDatum values are created by SchemaOptions.Encode.
+type EncodedDatum struct {
+	desc   protoreflect.MessageDescriptor
+	native interface{}
+}
+
+// Descriptor returns the descriptor of the message the datum was encoded from.
+func (d EncodedDatum) Descriptor() protoreflect.MessageDescriptor {
+	return d.desc
+}
+
+// Native returns the datum in the native Go form used by goavro.
+func (d EncodedDatum) Native() interface{} {
+	return d.native
+}
+
+// datumValidator checks native Go datums against an Avro schema.
+type datumValidator struct {
+	named map[string]avro.Schema
+}
+
+func newDatumValidator(schema avro.Schema) datumValidator {
+	v := datumValidator{named: make(map[string]avro.Schema)}
+	v.collectNamed(schema)
+	return v
+}
+
+func (v datumValidator) collectNamed(schema avro.Schema) {
+	switch s := schema.(type) {
+	case avro.Union:
+		for _, branch := range s {
+			v.collectNamed(branch)
+		}
+	case avro.Array:
+		v.collectNamed(s.Items)
+	case avro.Map:
+		v.collectNamed(s.Values)
+	case avro.Record:
+		v.named[fullName(s.Namespace, s.Name)] = s
+		for _, field := range s.Fields {
+			v.collectNamed(field.Type)
+		}
+	case avro.Enum:
+		v.named[fullName(s.Namespace, s.Name)] = s
+	case avro.Fixed:
+		v.named[fullName(s.Namespace, s.Name)] = s
+	}
+}
+
+func fullName(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + "." + name
+}
+
+// validate returns an error describing the first part of datum,
+// identified by path, that does not match the schema.
+func (v datumValidator) validate(schema avro.Schema, datum interface{}, path string) error {
+	switch s := schema.(type) {
+	case avro.Reference:
+		named, ok := v.named[string(s)]
+		if !ok {
+			return fmt.Errorf("%s: undefined type '%s'", path, s)
+		}
+		return v.validate(named, datum, path)
+	case avro.Union:
+		return v.validateUnion(s, datum, path)
+	case avro.Primitive:
+		return validatePrimitive(s, datum, path)
+	case avro.Record:
+		return v.validateRecord(s, datum, path)
+	case avro.Enum:
+		str, ok := datum.(string)
+		if !ok {
+			return fmt.Errorf("%s: expected enum symbol, got %T", path, datum)
+		}
+		for _, symbol := range s.Symbols {
+			if symbol == str {
+				return nil
+			}
+		}
+		return fmt.Errorf("%s: unknown symbol '%s' for enum '%s'", path, str, fullName(s.Namespace, s.Name))
+	case avro.Array:
+		list, ok := datum.([]interface{})
+		if !ok {
+			return fmt.Errorf("%s: expected array, got %T", path, datum)
+		}
+		for i, item := range list {
+			if err := v.validate(s.Items, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case avro.Map:
+		m, ok := datum.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s: expected map, got %T", path, datum)
+		}
+		for key, value := range m {
+			if err := v.validate(s.Values, value, fmt.Sprintf("%s[%q]", path, key)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case avro.Fixed:
+		var size int
+		switch b := datum.(type) {
+		case []byte:
+			size = len(b)
+		case string:
+			size = len(b)
+		default:
+			return fmt.Errorf("%s: expected fixed, got %T", path, datum)
+		}
+		if size != s.Size {
+			return fmt.Errorf("%s: expected %d bytes, got %d", path, s.Size, size)
+		}
+		return nil
+	}
+	return fmt.Errorf("%s: unsupported schema %T", path, schema)
+}
+
+func (v datumValidator) validateUnion(union avro.Union, datum interface{}, path string) error {
+	if datum == nil {
+		for _, branch := range union {
+			if p, ok := branch.(avro.Primitive); ok && p.Type == avro.NullType {
+				return nil
+			}
+		}
+		return fmt.Errorf("%s: null is not allowed", path)
+	}
+	m, ok := datum.(map[string]interface{})
+	if !ok || len(m) != 1 {
+		return fmt.Errorf("%s: expected union value as map with a single key, got %T", path, datum)
+	}
+	for key, value := range m {
+		for _, branch := range union {
+			if v.unionBranchName(branch) == key {
+				return v.validate(branch, value, path)
+			}
+		}
+		return fmt.Errorf("%s: unexpected union branch '%s'", path, key)
+	}
+	return nil
+}
+
+// unionBranchName returns the name goavro uses to select the union branch.
+func (v datumValidator) unionBranchName(schema avro.Schema) string {
+	switch s := schema.(type) {
+	case avro.Reference:
+		return string(s)
+	case avro.Primitive:
+		switch s.LogicalType {
+		case avro.DateLogicalType, avro.TimeMicrosLogicalType, avro.TimestampMicrosLogicalType:
+			return string(s.Type) + "." + string(s.LogicalType)
+		}
+		return string(s.Type)
+	case avro.Record:
+		return fullName(s.Namespace, s.Name)
+	case avro.Enum:
+		return fullName(s.Namespace, s.Name)
+	case avro.Fixed:
+		return fullName(s.Namespace, s.Name)
+	case avro.Array:
+		return string(avro.ArrayType)
+	case avro.Map:
+		return string(avro.MapType)
+	}
+	return ""
+}
+
+func (v datumValidator) validateRecord(record avro.Record, datum interface{}, path string) error {
+	m, ok := datum.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("%s: expected record, got %T", path, datum)
+	}
+	for _, field := range record.Fields {
+		value, ok := m[field.Name]
+		if !ok {
+			return fmt.Errorf("%s.%s: missing field", path, field.Name)
+		}
+		if err := v.validate(field.Type, value, path+"."+field.Name); err != nil {
+			return err
+		}
+	}
+	if len(m) > len(record.Fields) {
+		for name := range m {
+			if !hasField(record, name) {
+				return fmt.Errorf("%s.%s: unexpected field", path, name)
+			}
+		}
+	}
+	return nil
+}
+
+func hasField(record avro.Record, name string) bool {
+	for _, field := range record.Fields {
+		if field.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func validatePrimitive(p avro.Primitive, datum interface{}, path string) error {
+	var ok bool
+	switch p.Type {
+	case avro.NullType:
+		ok = datum == nil
+	case avro.BooleanType:
+		_, ok = datum.(bool)
+	case avro.IntType, avro.LongType:
+		switch datum.(type) {
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+			ok = true
+		case time.Time:
+			ok = p.LogicalType == avro.DateLogicalType || p.LogicalType == avro.TimestampMicrosLogicalType
+		case time.Duration:
+			ok = p.LogicalType == avro.TimeMicrosLogicalType
+		}
+	case avro.FloatType, avro.DoubleType:
+		switch datum.(type) {
+		case float32, float64, int, int32, int64:
+			ok = true
+		}
+	case avro.BytesType, avro.StringType:
+		switch datum.(type) {
+		case string, []byte:
+			ok = true
+		}
+	}
+	if !ok {
+		return fmt.Errorf("%s: expected %s, got %T", path, p.Type, datum)
+	}
+	return nil
+}
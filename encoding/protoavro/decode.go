@@ -2,6 +2,7 @@ package protoavro
 
 import (
 	"fmt"
+	"strings"
 
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
@@ -10,39 +11,73 @@ import (
 // decodeJSON decodes the JSON encoded avro data and places the
 // result in msg.
 func (o *SchemaOptions) decodeJSON(data interface{}, msg proto.Message) error {
-	return o.decodeMessage(data, msg.ProtoReflect())
+	mask, err := newFieldMask(o.FieldMask, msg.ProtoReflect().Descriptor())
+	if err != nil {
+		return err
+	}
+	o.rootMessage = msg.ProtoReflect().Descriptor().FullName()
+	if ok, err := o.decodeRawMessage(data, msg.ProtoReflect()); err != nil || ok {
+		return err
+	}
+	return o.decodeMessage(data, msg.ProtoReflect(), mask)
 }
 
-func (o *SchemaOptions) decodeMessage(data interface{}, msg protoreflect.Message) error {
+func (o *SchemaOptions) decodeMessage(data interface{}, msg protoreflect.Message, mask fieldMask) error {
 	if data == nil {
 		return nil
 	}
+	if o.isWKT(msg.Descriptor().FullName()) {
+		return o.decodeWKT(o.wrapWKT(data, msg.Descriptor()), msg)
+	}
 	d, ok := data.(map[string]interface{})
 	if !ok {
 		return fmt.Errorf("expected message encoded as map[string]interface{}, got %T", data)
 	}
-
-	if isWKT(msg.Descriptor().FullName()) {
-		return decodeWKT(d, msg)
-	}
 	// unwrap union
 	desc := msg.Descriptor()
-	if msgData, ok := d[string(desc.FullName())]; len(d) == 1 && ok {
-		return o.decodeMessage(msgData, msg)
+	if msgData, ok := o.unwrapMessage(d, desc); ok {
+		return o.decodeMessage(msgData, msg, mask)
 	}
 	for fieldName, fieldValue := range d {
-		fd, ok := findField(desc, fieldName)
+		if fieldName == unknownFieldName && desc.Fields().ByName(unknownFieldName) == nil {
+			if err := o.decodeUnknown(fieldValue, msg); err != nil {
+				return err
+			}
+			continue
+		}
+		if o.isRawMessageField(desc, fieldName) {
+			continue
+		}
+		fd, ok := o.findWriterField(desc, fieldName)
+		if !ok {
+			fd, ok = o.findField(desc, fieldName)
+		}
 		if !ok {
 			return fmt.Errorf("unexpected field %s", fieldName)
 		}
-		if err := o.decodeField(fieldValue, msg, fd); err != nil {
+		fieldMask, ok := mask.sub(fd)
+		if !ok {
+			continue
+		}
+		if err := o.decodeField(fieldValue, msg, fd, fieldMask); err != nil {
 			return err
 		}
 	}
+	for i := 0; i < desc.Fields().Len(); i++ {
+		fd := desc.Fields().Get(i)
+		if _, ok := mask.sub(fd); ok && isRequired(fd) && !msg.Has(fd) {
+			return fmt.Errorf("required field %s is not set", fd.FullName())
+		}
+	}
 	return nil
 }
 
-func (o *SchemaOptions) decodeField(data interface{}, val protoreflect.Message, f protoreflect.FieldDescriptor) error {
+func (o *SchemaOptions) decodeField(
+	data interface{},
+	val protoreflect.Message,
+	f protoreflect.FieldDescriptor,
+	mask fieldMask,
+) error {
 	if data == nil {
 		return nil
 	}
@@ -52,8 +87,7 @@ func (o *SchemaOptions) decodeField(data interface{}, val protoreflect.Message,
 		if err := o.decodeMap(data, f, mp); err != nil {
 			return err
 		}
-		val.Set(f, protoreflect.ValueOfMap(mp))
-		return nil
+		return o.setField(val, f, protoreflect.ValueOfMap(mp))
 	case f.IsList():
 		listData, err := decodeListLike(data, "array")
 		if err != nil {
@@ -65,21 +99,36 @@ func (o *SchemaOptions) decodeField(data interface{}, val protoreflect.Message,
 				list.Append(list.NewElement())
 				continue
 			}
-			fieldValue, err := o.decodeFieldKind(el, list.NewElement(), f)
+			fieldValue, err := o.decodeFieldKind(el, list.NewElement(), f, mask)
 			if err != nil {
 				return err
 			}
 			list.Append(fieldValue)
 		}
-		val.Set(f, protoreflect.ValueOfList(list))
-		return nil
+		return o.setField(val, f, protoreflect.ValueOfList(list))
 	default:
-		fieldValue, err := o.decodeFieldKind(data, val.NewField(f), f)
+		fieldValue, err := o.decodeFieldKind(data, val.NewField(f), f, mask)
 		if err != nil {
 			return err
 		}
-		val.Set(f, fieldValue)
+		return o.setField(val, f, fieldValue)
 	}
+}
+
+// setField sets the decoded value of a field, after applying the DecodeTransformer.
+func (o *SchemaOptions) setField(msg protoreflect.Message, f protoreflect.FieldDescriptor, value protoreflect.Value) error {
+	if o.DecodeTransformer != nil {
+		transformed, ok, err := o.DecodeTransformer(f, value)
+		if err != nil {
+			return fmt.Errorf("transform field %s: %w", f.FullName(), err)
+		}
+		if !ok {
+			msg.Clear(f)
+			return nil
+		}
+		value = transformed
+	}
+	msg.Set(f, value)
 	return nil
 }
 
@@ -87,14 +136,22 @@ func (o *SchemaOptions) decodeFieldKind(
 	data interface{},
 	mutable protoreflect.Value,
 	f protoreflect.FieldDescriptor,
+	mask fieldMask,
 ) (protoreflect.Value, error) {
 	switch f.Kind() {
 	case protoreflect.MessageKind, protoreflect.GroupKind:
-		if err := o.decodeMessage(data, mutable.Message()); err != nil {
+		if err := o.decodeMessage(data, mutable.Message(), mask); err != nil {
 			return protoreflect.Value{}, err
 		}
 		return mutable, nil
 	case protoreflect.StringKind:
+		if o.isUUID(f) {
+			str, err := decodeUUID(data)
+			if err != nil {
+				return protoreflect.Value{}, fmt.Errorf("field %s: %w", f.Name(), err)
+			}
+			return protoreflect.ValueOfString(str), nil
+		}
 		str, err := decodeStringLike(data, "string")
 		if err != nil {
 			return protoreflect.Value{}, fmt.Errorf("field %s: %w", f.Name(), err)
@@ -131,27 +188,41 @@ func (o *SchemaOptions) decodeFieldKind(
 		}
 		return protoreflect.ValueOfUint64(uint64(i)), nil
 	case protoreflect.BytesKind:
-		bs, err := decodeBytesLike(data, "bytes")
+		key := "bytes"
+		if m, ok := data.(map[string]interface{}); ok {
+			if _, ok := m[o.avroFullName(f)]; ok {
+				// encoded as fixed
+				key = o.avroFullName(f)
+			}
+		}
+		bs, err := decodeBytesLike(data, key)
 		if err != nil {
 			return protoreflect.Value{}, fmt.Errorf("field %s: %w", f.Name(), err)
 		}
 		return protoreflect.ValueOfBytes(bs), nil
 	case protoreflect.EnumKind:
-		str, err := decodeStringLike(data, string(f.Enum().FullName()))
-		if err != nil {
-			return protoreflect.Value{}, fmt.Errorf("field %s: %w", f.Name(), err)
-		}
-		if v := f.Enum().Values().ByName(protoreflect.Name(str)); v != nil {
-			return protoreflect.ValueOfEnum(v.Number()), nil
-		}
-		return protoreflect.ValueOfEnum(0), nil
+		return o.decodeEnum(data, f)
 	case protoreflect.DoubleKind:
+		if m, ok := data.(map[string]interface{}); ok {
+			dbl, err := decodeFloatLike(m, "double")
+			if err != nil {
+				return protoreflect.Value{}, fmt.Errorf("field %s: %w", f.Name(), err)
+			}
+			return protoreflect.ValueOfFloat64(dbl), nil
+		}
 		dbl, ok := data.(float64)
 		if !ok {
 			return protoreflect.Value{}, fmt.Errorf("field %s: expected float64, got %T", f.Name(), data)
 		}
 		return protoreflect.ValueOfFloat64(dbl), nil
 	case protoreflect.FloatKind:
+		if m, ok := data.(map[string]interface{}); ok {
+			flt, err := decodeFloatLike(m, "float")
+			if err != nil {
+				return protoreflect.Value{}, fmt.Errorf("field %s: %w", f.Name(), err)
+			}
+			return protoreflect.ValueOfFloat32(float32(flt)), nil
+		}
 		flt, ok := data.(float32)
 		if !ok {
 			return protoreflect.Value{}, fmt.Errorf("field %s: expected float32, got %T", f.Name(), data)
@@ -161,12 +232,68 @@ func (o *SchemaOptions) decodeFieldKind(
 	return protoreflect.Value{}, fmt.Errorf("unexpected kind %s", f.Kind())
 }
 
-func findField(desc protoreflect.MessageDescriptor, name string) (protoreflect.FieldDescriptor, bool) {
+// unwrapMessage returns the record in data, when data is a union value of the message
+// identified by its full name or one of its aliases.
+func (o *SchemaOptions) unwrapMessage(
+	data map[string]interface{},
+	desc protoreflect.MessageDescriptor,
+) (interface{}, bool) {
+	if len(data) != 1 {
+		return nil, false
+	}
+	if msgData, ok := data[o.avroFullName(desc)]; ok {
+		return msgData, true
+	}
+	if o.AliasesCallback == nil {
+		return nil, false
+	}
+	for _, alias := range o.AliasesCallback(desc) {
+		if !strings.Contains(alias, ".") {
+			alias = o.avroNamespace(desc) + "." + alias
+		}
+		if msgData, ok := data[alias]; ok {
+			return msgData, true
+		}
+	}
+	return nil, false
+}
+
+// findField returns the field with the name, which is either the Avro name,
+// the JSON name, text name or an alias of the field.
+func (o *SchemaOptions) findField(desc protoreflect.MessageDescriptor, name string) (protoreflect.FieldDescriptor, bool) {
+	if o.SanitizeNames || (o.Extensions != nil && desc.ExtensionRanges().Len() > 0) {
+		if fd, ok := o.recordNames(desc).fields[name]; ok {
+			return fd, true
+		}
+	}
 	if fd := desc.Fields().ByJSONName(name); fd != nil {
 		return fd, true
 	}
 	if fd := desc.Fields().ByTextName(name); fd != nil {
 		return fd, true
 	}
+	if o.AliasesCallback != nil {
+		fd, ok := o.aliasedFields(desc)[name]
+		return fd, ok
+	}
 	return nil, false
 }
+
+// aliasedFields returns the fields of the message by alias, built once per message.
+func (o *SchemaOptions) aliasedFields(desc protoreflect.MessageDescriptor) map[string]protoreflect.FieldDescriptor {
+	if fields, ok := o.fieldAliases[desc.FullName()]; ok {
+		return fields
+	}
+	fields := make(map[string]protoreflect.FieldDescriptor)
+	for i := 0; i < desc.Fields().Len(); i++ {
+		fd := desc.Fields().Get(i)
+		for _, alias := range o.AliasesCallback(fd) {
+			fields[alias] = fd
+		}
+	}
+	if o.fieldAliases == nil {
+		o.fieldAliases = make(map[protoreflect.FullName]map[string]protoreflect.FieldDescriptor)
+	}
+	o.fieldAliases[desc.FullName()] = fields
+	return fields
+}
@@ -0,0 +1,117 @@
+package protoavro
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/linkedin/goavro/v2"
+	"go.einride.tech/protobuf-avro/avro"
+	examplev1 "go.einride.tech/protobuf-avro/internal/examples/proto/gen/einride/avro/example/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+	"gotest.tools/v3/assert"
+)
+
+func Test_Editions(t *testing.T) {
+	opts := SchemaOptions{OmitRootElement: true}
+	desc := (&examplev1.ExampleEditions{}).ProtoReflect().Descriptor()
+
+	t.Run("schema", func(t *testing.T) {
+		schema, err := opts.InferSchema(desc)
+		assert.NilError(t, err)
+		fields := make(map[string]avro.Field)
+		for _, field := range schema.(avro.Record).Fields {
+			fields[field.Name] = field
+		}
+		assert.DeepEqual(t, avro.Nullable(avro.String()), fields["explicit_string"].Type)
+		assert.DeepEqual(t, avro.Nullable(avro.Integer()), fields["implicit_int32"].Type)
+		// LEGACY_REQUIRED fields are required fields
+		assert.DeepEqual(t, avro.String(), fields["required_string"].Type)
+		assert.DeepEqual(t, avro.Union{avro.Integer(), avro.Null()}, fields["default_int32"].Type)
+		assert.Equal(t, int32(7), fields["default_int32"].Default)
+		// DELIMITED messages are mapped like other messages
+		assert.Equal(t, "Nested", fields["delimited_message"].Type.(avro.Union)[1].(avro.Record).Name)
+	})
+
+	t.Run("presence", func(t *testing.T) {
+		encoded, err := opts.encodeJSON(&examplev1.ExampleEditions{RequiredString: proto.String("")})
+		assert.NilError(t, err)
+		record := encoded.(map[string]interface{})
+		// fields with explicit presence are null when not set
+		assert.Assert(t, record["explicit_string"] == nil)
+		assert.Assert(t, record["closed_enum"] == nil)
+		// fields with implicit presence are encoded with their zero values
+		assert.DeepEqual(t, map[string]interface{}{"int": int32(0)}, record["implicit_int32"])
+		assert.DeepEqual(
+			t,
+			map[string]interface{}{"einride.avro.example.v1.ExampleEditions.OpenEnum": "OPEN_VALUE_UNSPECIFIED"},
+			record["implicit_open_enum"],
+		)
+	})
+
+	t.Run("roundtrip", func(t *testing.T) {
+		msg := &examplev1.ExampleEditions{
+			ExplicitString:   proto.String(""),
+			ImplicitInt32:    1,
+			RequiredString:   proto.String("required"),
+			DefaultInt32:     proto.Int32(0),
+			ClosedEnum:       examplev1.ExampleEditions_CLOSED_VALUE2.Enum(),
+			OpenEnum:         examplev1.ExampleEditions_OPEN_VALUE_UNSPECIFIED.Enum(),
+			ImplicitOpenEnum: examplev1.ExampleEditions_OPEN_VALUE1,
+			RepeatedInt64:    []int64{1},
+			DelimitedMessage: &examplev1.ExampleEditions_Nested{NestedString: proto.String("nested")},
+		}
+		schema, err := opts.InferSchema(desc)
+		assert.NilError(t, err)
+		schemaBytes, err := json.Marshal(schema)
+		assert.NilError(t, err)
+		codec, err := goavro.NewCodec(string(schemaBytes))
+		assert.NilError(t, err)
+		encoded, err := opts.encodeJSON(msg)
+		assert.NilError(t, err)
+		binary, err := codec.BinaryFromNative(nil, encoded)
+		assert.NilError(t, err)
+		native, _, err := codec.NativeFromBinary(binary)
+		assert.NilError(t, err)
+		decoded := &examplev1.ExampleEditions{}
+		assert.NilError(t, opts.decodeJSON(native, decoded))
+		assert.DeepEqual(t, msg, decoded, protocmp.Transform())
+	})
+
+	t.Run("unknown values of closed enums", func(t *testing.T) {
+		msg := &examplev1.ExampleEditions{
+			RequiredString: proto.String(""),
+			ClosedEnum:     examplev1.ExampleEditions_ClosedEnum(3).Enum(),
+			OpenEnum:       examplev1.ExampleEditions_OpenEnum(3).Enum(),
+		}
+		opts := SchemaOptions{OmitRootElement: true, EnumEncoding: EnumNumber, UnknownEnum: UnknownEnumPreserve}
+		_, err := opts.encodeJSON(msg)
+		assert.Error(t, err, "field closed_enum: unknown value 3 of enum einride.avro.example.v1.ExampleEditions.ClosedEnum")
+		msg.ClosedEnum = nil
+		encoded, err := opts.encodeJSON(msg)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, map[string]interface{}{"int": int32(3)}, encoded.(map[string]interface{})["open_enum"])
+	})
+
+	t.Run("enum_type of files", func(t *testing.T) {
+		msg := &examplev1.ExampleEditionsFileFeatures{
+			FileEnum:   examplev1.FileEnum(3).Enum(),
+			NestedEnum: examplev1.ExampleEditionsFileFeatures_NestedEnum(3).Enum(),
+			OpenEnum:   examplev1.OpenFileEnum(3).Enum(),
+		}
+		opts := SchemaOptions{OmitRootElement: true, EnumEncoding: EnumNumber, UnknownEnum: UnknownEnumPreserve}
+		_, err := opts.encodeJSON(msg)
+		assert.Error(t, err, "field file_enum: unknown value 3 of enum einride.avro.example.v1.FileEnum")
+		msg.FileEnum = nil
+		_, err = opts.encodeJSON(msg)
+		assert.Error(
+			t,
+			err,
+			"field nested_enum: unknown value 3 of enum einride.avro.example.v1.ExampleEditionsFileFeatures.NestedEnum",
+		)
+		msg.NestedEnum = nil
+		encoded, err := opts.encodeJSON(msg)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, map[string]interface{}{"int": int32(3)}, encoded.(map[string]interface{})["open_enum"])
+	})
+}
@@ -8,7 +8,11 @@ import (
 )
 
 func (o SchemaOptions) encodeJSON(message proto.Message) (interface{}, error) {
-	return o.messageJSON(message.ProtoReflect(), 0, true)
+	mask, err := newFieldMask(o.FieldMask, message.ProtoReflect().Descriptor())
+	if err != nil {
+		return nil, err
+	}
+	return o.withRoot(message.ProtoReflect().Descriptor()).messageJSON(message.ProtoReflect(), mask, 0, true)
 }
 
 func (o SchemaOptions) unionValue(key string, value interface{}) map[string]interface{} {
@@ -17,11 +21,16 @@ func (o SchemaOptions) unionValue(key string, value interface{}) map[string]inte
 	}
 }
 
-func (o SchemaOptions) messageJSON(message protoreflect.Message, recursiveIndex int, useUnion bool) (interface{}, error) {
+func (o SchemaOptions) messageJSON(
+	message protoreflect.Message,
+	mask fieldMask,
+	recursiveIndex int,
+	useUnion bool,
+) (interface{}, error) {
 	if !message.IsValid() {
 		return nil, nil
 	}
-	if isWKT(message.Descriptor().FullName()) {
+	if o.isWKT(message.Descriptor().FullName()) {
 		value, err := o.encodeWKT(message, useUnion)
 		if err != nil {
 			return nil, err
@@ -29,59 +38,111 @@ func (o SchemaOptions) messageJSON(message protoreflect.Message, recursiveIndex
 		return value, nil
 	}
 
-	n := string(message.Descriptor().Name())
-	ns := namespace(message.Descriptor())
-	fullName := fmt.Sprintf("%s.%s", ns, n)
-
 	desc := message.Descriptor()
+	var fieldNames []string
+	if o.SanitizeNames {
+		fieldNames = o.fieldNames(desc)
+	}
 	record := make(map[string]interface{}, desc.Fields().Len())
-	for i := 0; i < desc.Fields().Len(); i++ {
-		field := desc.Fields().Get(i)
-		if field.ContainingOneof() != nil {
-			if !message.Has(field) {
-				// dont populate scalar fields belonging to
-				// a oneof (.Get returns the default value)
-				record[string(field.Name())] = nil
-			} else {
-				value := message.Get(field)
-				jsonValue, err := o.fieldJSON(field, value, recursiveIndex+1)
+	for i, field := range o.recordFields(desc) {
+		fieldMask, ok := mask.sub(field)
+		if !ok {
+			continue
+		}
+		name := o.fieldName(field, fieldNames, i)
+		missing := isRequired(field) && !message.Has(field)
+		if !missing && field.HasPresence() && !message.Has(field) && (field.ContainingOneof() != nil || field.Message() == nil) {
+			// dont populate unset scalar fields with presence, such as fields
+			// belonging to a oneof (.Get returns the default value)
+			record[name] = nil
+			continue
+		}
+		value := message.Get(field)
+		if o.EncodeTransformer != nil {
+			transformed, ok, err := o.EncodeTransformer(field, value)
+			if err != nil {
+				return nil, fmt.Errorf("transform field %s: %w", field.FullName(), err)
+			}
+			if !ok {
+				dropped, err := o.droppedFieldJSON(message, field, fieldMask, recursiveIndex+1)
 				if err != nil {
 					return nil, err
 				}
-				record[string(field.Name())] = jsonValue
+				record[name] = dropped
+				continue
 			}
-			continue
+			value = transformed
 		}
-		value := message.Get(field)
-		jsonValue, err := o.fieldJSON(field, value, recursiveIndex+1)
+		// unset required fields can be dropped by the transformer
+		if missing {
+			return nil, fmt.Errorf("required field %s is not set", field.FullName())
+		}
+		jsonValue, err := o.fieldJSON(field, value, fieldMask, recursiveIndex+1)
+		if err != nil {
+			return nil, err
+		}
+		record[name] = jsonValue
+	}
+	if o.preserveUnknown(desc, mask) {
+		record[unknownFieldName] = o.encodeUnknown(message)
+	}
+	if o.rawMessage(desc) {
+		raw, err := o.encodeRawMessage(message, recursiveIndex)
 		if err != nil {
 			return nil, err
 		}
-		record[string(field.Name())] = jsonValue
+		record[o.RawMessage.name()] = raw
 	}
 	if (o.OmitRootElement && recursiveIndex == 0) || !useUnion {
 		return record, nil
 	}
 	return map[string]interface{}{
-		string(fullName): record,
+		o.avroFullName(desc): record,
 	}, nil
 }
 
+// droppedFieldJSON returns the value of a field dropped by a FieldTransformer, which is null
+// or the zero value of the field type when the type is not nullable.
+func (o SchemaOptions) droppedFieldJSON(
+	message protoreflect.Message,
+	field protoreflect.FieldDescriptor,
+	mask fieldMask,
+	recursiveIndex int,
+) (interface{}, error) {
+	switch {
+	case field.IsList() && o.omitNullArray():
+		return []interface{}{}, nil
+	case isRequired(field):
+		return o.fieldJSON(field, o.zeroValue(message, field), mask, recursiveIndex)
+	}
+	return nil, nil
+}
+
+// zeroValue returns the value that dropped required fields are encoded with,
+// which is valid for the Avro type of the field.
+func (o SchemaOptions) zeroValue(message protoreflect.Message, field protoreflect.FieldDescriptor) protoreflect.Value {
+	if size, ok := o.fixedSize(field); ok {
+		return protoreflect.ValueOfBytes(make([]byte, size))
+	}
+	return message.NewField(field)
+}
+
 func (o SchemaOptions) fieldJSON(
 	field protoreflect.FieldDescriptor,
 	value protoreflect.Value,
+	mask fieldMask,
 	recursiveIndex int,
 ) (interface{}, error) {
 	if field.IsList() {
 		list := make([]interface{}, 0, value.List().Len())
 		for i := 0; i < value.List().Len(); i++ {
 			v := value.List().Get(i)
-			fieldValue, err := o.fieldKindJSON(field, v, recursiveIndex, !o.OmitNullArray)
+			fieldValue, err := o.fieldKindJSON(field, v, mask, recursiveIndex, !o.omitNullArray())
 			if err != nil {
 				return nil, err
 			}
 
-			if o.OmitNullArray {
+			if o.omitNullArray() {
 				if fieldValue == nil {
 					continue
 				}
@@ -90,7 +151,7 @@ func (o SchemaOptions) fieldJSON(
 			list = append(list, fieldValue)
 		}
 
-		if o.OmitNullArray {
+		if o.omitNullArray() {
 			return list, nil
 		}
 
@@ -99,7 +160,8 @@ func (o SchemaOptions) fieldJSON(
 	if field.IsMap() {
 		return o.encodeMap(field, value.Map(), recursiveIndex)
 	}
-	return o.fieldKindJSON(field, value, recursiveIndex, true)
+	// required fields are not nullable
+	return o.fieldKindJSON(field, value, mask, recursiveIndex, !isRequired(field))
 }
 
 func (o SchemaOptions) maybeUnionValue(key string, value interface{}, selector bool) interface{} {
@@ -112,25 +174,20 @@ func (o SchemaOptions) maybeUnionValue(key string, value interface{}, selector b
 func (o SchemaOptions) fieldKindJSON(
 	field protoreflect.FieldDescriptor,
 	value protoreflect.Value,
+	mask fieldMask,
 	recursiveIndex int,
 	useUnion bool,
 ) (interface{}, error) {
 
 	switch field.Kind() {
 	case protoreflect.MessageKind, protoreflect.GroupKind:
-		return o.messageJSON(value.Message(), recursiveIndex, useUnion)
+		return o.messageJSON(value.Message(), mask, recursiveIndex, useUnion)
 	case protoreflect.EnumKind:
-		if field.Enum().Values().ByNumber(value.Enum()) == nil {
-			return o.maybeUnionValue(
-				string(field.Enum().FullName()),
-				string(field.Enum().Values().ByNumber(protoreflect.EnumNumber(0)).Name()),
-				useUnion,
-			), nil
-		}
-		return o.maybeUnionValue(
-			string(field.Enum().FullName()),
-			string(field.Enum().Values().ByNumber(value.Enum()).Name()), useUnion), nil
+		return o.enumJSON(field, value.Enum(), useUnion)
 	case protoreflect.StringKind:
+		if o.isUUID(field) {
+			return o.uuidJSON(field, value.String(), useUnion)
+		}
 		return o.maybeUnionValue("string", value.String(), useUnion), nil
 	case protoreflect.Int32Kind,
 		protoreflect.Sfixed32Kind,
@@ -149,6 +206,9 @@ func (o SchemaOptions) fieldKindJSON(
 	case protoreflect.BoolKind:
 		return o.maybeUnionValue("boolean", value.Bool(), useUnion), nil
 	case protoreflect.BytesKind:
+		if size, ok := o.FixedSizes[field.FullName()]; ok {
+			return o.fixedJSON(field, value.Bytes(), size, useUnion)
+		}
 		return o.maybeUnionValue("bytes", value.Bytes(), useUnion), nil
 	case protoreflect.DoubleKind:
 		return o.maybeUnionValue("double", value.Float(), useUnion), nil
@@ -157,3 +217,19 @@ func (o SchemaOptions) fieldKindJSON(
 	}
 	return value.Interface(), nil
 }
+
+func (o SchemaOptions) fixedJSON(
+	field protoreflect.FieldDescriptor,
+	value []byte,
+	size int,
+	useUnion bool,
+) (interface{}, error) {
+	if len(value) == 0 && useUnion {
+		// unset fixed size fields are encoded as null
+		return nil, nil
+	}
+	if len(value) != size {
+		return nil, fmt.Errorf("field %s: expected %d bytes, got %d", field.Name(), size, len(value))
+	}
+	return o.maybeUnionValue(o.avroFullName(field), value, useUnion), nil
+}
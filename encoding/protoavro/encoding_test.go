@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/linkedin/goavro/v2"
+	"go.einride.tech/protobuf-avro/avro"
 	examplev1 "go.einride.tech/protobuf-avro/internal/examples/proto/gen/einride/avro/example/v1"
 	"google.golang.org/genproto/googleapis/example/library/v1"
 	"google.golang.org/genproto/googleapis/type/date"
@@ -13,6 +14,7 @@ import (
 	"google.golang.org/genproto/googleapis/type/timeofday"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/testing/protocmp"
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/durationpb"
@@ -1255,6 +1257,66 @@ func Test_JSON_Options(t *testing.T) {
 	}
 }
 
+func Test_Fixed(t *testing.T) {
+	opts := SchemaOptions{
+		OmitRootElement: true,
+		FixedSizes: map[protoreflect.FullName]int{
+			"einride.avro.example.v1.ExampleBytes.bytes": 4,
+		},
+	}
+	msg := &examplev1.ExampleBytes{Bytes: []byte{1, 2, 3, 4}}
+
+	schema, err := opts.InferSchema(msg.ProtoReflect().Descriptor())
+	assert.NilError(t, err)
+	assert.DeepEqual(t, avro.Record{
+		Type:      avro.RecordType,
+		Name:      "ExampleBytes",
+		Namespace: "einride.avro.example.v1",
+		Fields: []avro.Field{
+			{
+				Name: "bytes",
+				Type: avro.Nullable(avro.Fixed{
+					Type:      avro.FixedType,
+					Name:      "bytes",
+					Namespace: "einride.avro.example.v1.ExampleBytes",
+					Size:      4,
+				}),
+				Default: avro.NullDefault,
+				Props:   numberProps(1),
+			},
+		},
+	}, schema)
+
+	got, err := opts.encodeJSON(msg)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, map[string]interface{}{
+		"bytes": map[string]interface{}{
+			"einride.avro.example.v1.ExampleBytes.bytes": []byte{1, 2, 3, 4},
+		},
+	}, got)
+
+	// assert that it round trips through goavro
+	schemaBytes, err := json.Marshal(schema)
+	assert.NilError(t, err)
+	codec, err := goavro.NewCodec(string(schemaBytes))
+	assert.NilError(t, err)
+	binary, err := codec.BinaryFromNative(nil, got)
+	assert.NilError(t, err)
+	native, _, err := codec.NativeFromBinary(binary)
+	assert.NilError(t, err)
+	var decoded examplev1.ExampleBytes
+	assert.NilError(t, opts.decodeJSON(native, &decoded))
+	assert.DeepEqual(t, msg, &decoded, protocmp.Transform())
+
+	// unset fields are encoded as null
+	got, err = opts.encodeJSON(&examplev1.ExampleBytes{})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, map[string]interface{}{"bytes": nil}, got)
+
+	_, err = opts.encodeJSON(&examplev1.ExampleBytes{Bytes: []byte{1, 2, 3}})
+	assert.ErrorContains(t, err, "field bytes: expected 4 bytes, got 3")
+}
+
 func mustAny(t *testing.T, msg proto.Message) *anypb.Any {
 	a, err := anypb.New(msg)
 	assert.NilError(t, err)
@@ -0,0 +1,210 @@
+package protoavro
+
+import (
+	"fmt"
+
+	"go.einride.tech/protobuf-avro/avro"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// EnumEncoding determines how protobuf enums are encoded in Avro.
+type EnumEncoding int
+
+const (
+	// EnumSymbol encodes enums as Avro enums of the names of the enum values.
+	EnumSymbol EnumEncoding = iota
+	// EnumNumber encodes enums as the int numbers of the enum values.
+	EnumNumber
+	// EnumRecord encodes enums as records with the number and the name of the enum value.
+	// The name is null for unknown enum values.
+	EnumRecord
+)
+
+// UnknownEnumPolicy determines how enum values that are unknown to the
+// enum descriptor are handled when encoding and decoding.
+type UnknownEnumPolicy int
+
+const (
+	// UnknownEnumZero replaces unknown enum values with the default enum value.
+	UnknownEnumZero UnknownEnumPolicy = iota
+	// UnknownEnumFail fails encoding and decoding of unknown enum values.
+	UnknownEnumFail
+	// UnknownEnumPreserve keeps the numbers of unknown enum values.
+	// With EnumSymbol, where the number cannot be represented, and for closed enums,
+	// which cannot hold unknown values, unknown values fail like with UnknownEnumFail.
+	UnknownEnumPreserve
+)
+
+// defaultEnumValue returns the default value of the enum, which is its first value.
+func defaultEnumValue(enum protoreflect.EnumDescriptor) protoreflect.EnumValueDescriptor {
+	return enum.Values().Get(0)
+}
+
+func (s schemaInferrer) inferEnumSchema(enum protoreflect.EnumDescriptor) (avro.Schema, error) {
+	if s.opts.EnumEncoding == EnumNumber {
+		return avro.Integer(), nil
+	}
+	n := s.opts.avroName(enum)
+	ns := s.opts.avroNamespace(enum)
+	fullName := fmt.Sprintf("%s.%s", ns, n)
+
+	if _, ok := s.seen[fullName]; ok {
+		return avro.Reference(fullName), nil
+	}
+	s.seen[fullName] = ""
+	symbols := s.opts.enumSymbols(enum)
+	if err := checkNames(ns, n, symbols); err != nil {
+		return nil, fmt.Errorf("enum '%s': %w", enum.FullName(), err)
+	}
+	doc := s.getDocs(enum)
+	if s.opts.EnumEncoding == EnumRecord {
+		return avro.Record{
+			Type:      avro.RecordType,
+			Doc:       doc,
+			Name:      n,
+			Namespace: ns,
+			Props:     s.getProps(enum),
+			Fields: []avro.Field{
+				{Name: "number", Type: avro.Integer(), Default: 0},
+				{Name: "name", Type: avro.Nullable(avro.String()), Default: avro.NullDefault},
+			},
+		}, nil
+	}
+	return avro.Enum{
+		Type:      avro.EnumType,
+		Doc:       doc,
+		Name:      n,
+		Namespace: ns,
+		Symbols:   symbols,
+		Default:   symbols[defaultEnumValue(enum).Index()],
+		Props:     s.getProps(enum),
+	}, nil
+}
+
+// resolveEnum returns the enum value to encode or decode for the number,
+// or nil when the number is unknown and preserved.
+func (o SchemaOptions) resolveEnum(
+	field protoreflect.FieldDescriptor,
+	number protoreflect.EnumNumber,
+) (protoreflect.EnumValueDescriptor, error) {
+	if value := field.Enum().Values().ByNumber(number); value != nil {
+		return value, nil
+	}
+	switch o.UnknownEnum {
+	case UnknownEnumPreserve:
+		// closed enums, such as proto2 enums, can not hold unknown values
+		if o.EnumEncoding != EnumSymbol && !isClosedEnum(field.Enum()) {
+			return nil, nil
+		}
+	case UnknownEnumZero:
+		return defaultEnumValue(field.Enum()), nil
+	}
+	return nil, fmt.Errorf("field %s: unknown value %d of enum %s", field.Name(), number, field.Enum().FullName())
+}
+
+func (o SchemaOptions) enumJSON(
+	field protoreflect.FieldDescriptor,
+	number protoreflect.EnumNumber,
+	useUnion bool,
+) (interface{}, error) {
+	value, err := o.resolveEnum(field, number)
+	if err != nil {
+		return nil, err
+	}
+	if value != nil {
+		number = value.Number()
+	}
+	switch o.EnumEncoding {
+	case EnumNumber:
+		return o.maybeUnionValue("int", int32(number), useUnion), nil
+	case EnumRecord:
+		var name interface{}
+		if value != nil {
+			name = o.unionValue("string", string(value.Name()))
+		}
+		return o.maybeUnionValue(o.avroFullName(field.Enum()), map[string]interface{}{
+			"number": int32(number),
+			"name":   name,
+		}, useUnion), nil
+	}
+	enumName := o.avroFullName(field.Enum())
+	return o.maybeUnionValue(enumName, o.enumSymbol(value), useUnion), nil
+}
+
+func (o SchemaOptions) decodeEnum(data interface{}, f protoreflect.FieldDescriptor) (protoreflect.Value, error) {
+	var number protoreflect.EnumNumber
+	switch o.EnumEncoding {
+	case EnumNumber:
+		i, err := decodeIntLike(data, "int")
+		if err != nil {
+			return protoreflect.Value{}, fmt.Errorf("field %s: %w", f.Name(), err)
+		}
+		number = protoreflect.EnumNumber(i)
+	case EnumRecord:
+		if m, ok := data.(map[string]interface{}); ok {
+			if record, ok := m[o.avroFullName(f.Enum())]; ok {
+				data = record
+			}
+		}
+		record, ok := data.(map[string]interface{})
+		if !ok {
+			return protoreflect.Value{}, fmt.Errorf("field %s: expected record, got %T", f.Name(), data)
+		}
+		i, err := decodeIntLike(record["number"], "int")
+		if err != nil {
+			return protoreflect.Value{}, fmt.Errorf("field %s: number: %w", f.Name(), err)
+		}
+		number = protoreflect.EnumNumber(i)
+	default:
+		str, err := decodeStringLike(data, o.avroFullName(f.Enum()))
+		if err != nil {
+			return protoreflect.Value{}, fmt.Errorf("field %s: %w", f.Name(), err)
+		}
+		if value := o.enumValueBySymbol(f.Enum(), str); value != nil {
+			return protoreflect.ValueOfEnum(value.Number()), nil
+		}
+		if o.UnknownEnum == UnknownEnumZero {
+			return protoreflect.ValueOfEnum(defaultEnumValue(f.Enum()).Number()), nil
+		}
+		return protoreflect.Value{}, fmt.Errorf("field %s: unknown symbol '%s' of enum %s", f.Name(), str, f.Enum().FullName())
+	}
+	value, err := o.resolveEnum(f, number)
+	if err != nil {
+		return protoreflect.Value{}, err
+	}
+	if value != nil {
+		number = value.Number()
+	}
+	return protoreflect.ValueOfEnum(number), nil
+}
+
+// isClosedEnum reports whether the enum is closed. The protobuf runtime does not resolve the
+// enum_type feature in generated code, so the feature is read from the options of the enum,
+// or else inherited from the options of its parent messages and file.
+func isClosedEnum(enum protoreflect.EnumDescriptor) bool {
+	for d := protoreflect.Descriptor(enum); d != nil; d = d.Parent() {
+		if enumType := featureEnumType(d.Options()); enumType != nil {
+			return *enumType == descriptorpb.FeatureSet_CLOSED
+		}
+	}
+	return enum.IsClosed()
+}
+
+// featureEnumType returns the enum_type feature set in the options of an enum, message or file,
+// or nil when it is not set.
+func featureEnumType(options protoreflect.ProtoMessage) *descriptorpb.FeatureSet_EnumType {
+	var features *descriptorpb.FeatureSet
+	switch o := options.(type) {
+	case *descriptorpb.EnumOptions:
+		features = o.GetFeatures()
+	case *descriptorpb.MessageOptions:
+		features = o.GetFeatures()
+	case *descriptorpb.FileOptions:
+		features = o.GetFeatures()
+	}
+	if features == nil {
+		return nil
+	}
+	return features.EnumType
+}
@@ -0,0 +1,138 @@
+package protoavro
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/linkedin/goavro/v2"
+	"go.einride.tech/protobuf-avro/avro"
+	examplev1 "go.einride.tech/protobuf-avro/internal/examples/proto/gen/einride/avro/example/v1"
+	"google.golang.org/protobuf/testing/protocmp"
+	"gotest.tools/v3/assert"
+)
+
+func Test_EnumEncoding(t *testing.T) {
+	unknown := examplev1.ExampleEnum_Enum(42)
+	for _, tt := range []struct {
+		name        string
+		opts        SchemaOptions
+		msg         *examplev1.ExampleEnum
+		expected    *examplev1.ExampleEnum
+		errContains string
+	}{
+		{
+			name: "symbol",
+			msg:  &examplev1.ExampleEnum{EnumValue: examplev1.ExampleEnum_ENUM_VALUE2},
+		},
+		{
+			name:     "symbol unknown zero",
+			msg:      &examplev1.ExampleEnum{EnumValue: unknown},
+			expected: &examplev1.ExampleEnum{},
+		},
+		{
+			name:        "symbol unknown fail",
+			opts:        SchemaOptions{UnknownEnum: UnknownEnumFail},
+			msg:         &examplev1.ExampleEnum{EnumValue: unknown},
+			errContains: "field enum_value: unknown value 42 of enum einride.avro.example.v1.ExampleEnum.Enum",
+		},
+		{
+			name:        "symbol unknown preserve",
+			opts:        SchemaOptions{UnknownEnum: UnknownEnumPreserve},
+			msg:         &examplev1.ExampleEnum{EnumValue: unknown},
+			errContains: "field enum_value: unknown value 42",
+		},
+		{
+			name: "number",
+			opts: SchemaOptions{EnumEncoding: EnumNumber},
+			msg:  &examplev1.ExampleEnum{EnumValue: examplev1.ExampleEnum_ENUM_VALUE3},
+		},
+		{
+			name: "number unknown preserve",
+			opts: SchemaOptions{EnumEncoding: EnumNumber, UnknownEnum: UnknownEnumPreserve},
+			msg:  &examplev1.ExampleEnum{EnumValue: unknown},
+		},
+		{
+			name:     "number unknown zero",
+			opts:     SchemaOptions{EnumEncoding: EnumNumber},
+			msg:      &examplev1.ExampleEnum{EnumValue: unknown},
+			expected: &examplev1.ExampleEnum{},
+		},
+		{
+			name: "record",
+			opts: SchemaOptions{EnumEncoding: EnumRecord},
+			msg:  &examplev1.ExampleEnum{EnumValue: examplev1.ExampleEnum_ENUM_VALUE1},
+		},
+		{
+			name: "record unknown preserve",
+			opts: SchemaOptions{EnumEncoding: EnumRecord, UnknownEnum: UnknownEnumPreserve},
+			msg:  &examplev1.ExampleEnum{EnumValue: unknown},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			schema, err := tt.opts.InferSchema(tt.msg.ProtoReflect().Descriptor())
+			assert.NilError(t, err)
+			schemaBytes, err := json.Marshal(schema)
+			assert.NilError(t, err)
+			codec, err := goavro.NewCodec(string(schemaBytes))
+			assert.NilError(t, err)
+
+			encoded, err := tt.opts.encodeJSON(tt.msg)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			assert.NilError(t, err)
+			binary, err := codec.BinaryFromNative(nil, encoded)
+			assert.NilError(t, err)
+			native, _, err := codec.NativeFromBinary(binary)
+			assert.NilError(t, err)
+
+			var decoded examplev1.ExampleEnum
+			assert.NilError(t, tt.opts.decodeJSON(native, &decoded))
+			expected := tt.expected
+			if expected == nil {
+				expected = tt.msg
+			}
+			assert.DeepEqual(t, expected, &decoded, protocmp.Transform())
+		})
+	}
+}
+
+func Test_EnumRecordSchema(t *testing.T) {
+	opts := SchemaOptions{OmitRootElement: true, EnumEncoding: EnumRecord}
+	schema, err := opts.InferSchema((&examplev1.ExampleEnum{}).ProtoReflect().Descriptor())
+	assert.NilError(t, err)
+	assert.DeepEqual(t, avro.Record{
+		Type:      avro.RecordType,
+		Name:      "ExampleEnum",
+		Namespace: "einride.avro.example.v1",
+		Fields: []avro.Field{
+			{
+				Name: "enum_value",
+				Type: avro.Nullable(avro.Record{
+					Type:      avro.RecordType,
+					Name:      "Enum",
+					Namespace: "einride.avro.example.v1.ExampleEnum",
+					Fields: []avro.Field{
+						{Name: "number", Type: avro.Integer(), Default: 0},
+						{Name: "name", Type: avro.Nullable(avro.String()), Default: avro.NullDefault},
+					},
+				}),
+				Default: avro.NullDefault,
+				Props:   numberProps(1),
+			},
+		},
+	}, schema)
+}
+
+func Test_DecodeUnknownEnumSymbol(t *testing.T) {
+	data := map[string]interface{}{
+		"enum_value": map[string]interface{}{"einride.avro.example.v1.ExampleEnum.Enum": "ENUM_VALUE4"},
+	}
+	var msg examplev1.ExampleEnum
+	assert.NilError(t, (&SchemaOptions{}).decodeJSON(data, &msg))
+	assert.Equal(t, examplev1.ExampleEnum_ENUM_UNSPECIFIED, msg.GetEnumValue())
+	err := (&SchemaOptions{UnknownEnum: UnknownEnumFail}).decodeJSON(data, &msg)
+	assert.ErrorContains(t, err, "field enum_value: unknown symbol 'ENUM_VALUE4' of enum einride.avro.example.v1.ExampleEnum.Enum")
+}
@@ -0,0 +1,129 @@
+package protoavro
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"hash/fnv"
+	"math"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// fieldNumberProp is the property of fields with their protobuf field numbers.
+const fieldNumberProp = "proto.number"
+
+// fieldID returns the Iceberg field ID of the field. Fields of the root message are identified
+// by their numbers. Fields of other messages are identified by their numbers namespaced by the
+// full names of their messages, so that the IDs of fields don't change when other fields are added.
+func (o SchemaOptions) fieldID(field protoreflect.FieldDescriptor) int {
+	if field.ContainingMessage().FullName() == o.rootMessage {
+		return int(field.Number())
+	}
+	return namespacedID(field.ContainingMessage().FullName(), field.Number())
+}
+
+// elementID returns the Iceberg element ID of the list field, identified by
+// the full name of the field and the number 0, which is not a valid field number.
+func elementID(field protoreflect.FieldDescriptor) int {
+	return namespacedID(field.FullName(), 0)
+}
+
+// namespacedID returns an ID derived from the hash of the namespace and the number,
+// which is larger than the largest field number and fits in an int32.
+func namespacedID(namespace protoreflect.FullName, number protoreflect.FieldNumber) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(namespace))
+	_ = binary.Write(h, binary.BigEndian, uint32(number))
+	const maxNumber = int64(protowire.MaxValidNumber)
+	return int(maxNumber + 1 + int64(h.Sum32())%(math.MaxInt32-maxNumber))
+}
+
+// writerField is the field ID and field number of a field in the schema of the file being decoded.
+type writerField struct {
+	id     int
+	number protoreflect.FieldNumber
+}
+
+// writerFields returns the field IDs and numbers of the fields of the records in the JSON encoded
+// schema, by full record name and field name. Fields without IDs and numbers are left out.
+func writerFields(schema string) (map[string]map[string]writerField, error) {
+	var v interface{}
+	if err := json.Unmarshal([]byte(schema), &v); err != nil {
+		return nil, err
+	}
+	fields := make(map[string]map[string]writerField)
+	collectWriterFields(v, "", fields)
+	return fields, nil
+}
+
+func collectWriterFields(v interface{}, namespace string, fields map[string]map[string]writerField) {
+	switch s := v.(type) {
+	case []interface{}:
+		for _, branch := range s {
+			collectWriterFields(branch, namespace, fields)
+		}
+	case map[string]interface{}:
+		switch s["type"] {
+		case "record":
+			name, _ := s["name"].(string)
+			if ns, ok := s["namespace"].(string); ok {
+				namespace = ns
+			}
+			if i := strings.LastIndex(name, "."); i >= 0 {
+				namespace, name = name[:i], name[i+1:]
+			}
+			full := name
+			if namespace != "" {
+				full = namespace + "." + name
+			}
+			recordFields, _ := s["fields"].([]interface{})
+			for _, f := range recordFields {
+				field, ok := f.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				id, hasID := field["field-id"].(float64)
+				number, hasNumber := field[fieldNumberProp].(float64)
+				if hasID || hasNumber {
+					if fields[full] == nil {
+						fields[full] = make(map[string]writerField)
+					}
+					fieldName, _ := field["name"].(string)
+					fields[full][fieldName] = writerField{id: int(id), number: protoreflect.FieldNumber(number)}
+				}
+				collectWriterFields(field["type"], namespace, fields)
+			}
+		case "array":
+			collectWriterFields(s["items"], namespace, fields)
+		case "map":
+			collectWriterFields(s["values"], namespace, fields)
+		}
+	}
+}
+
+// findWriterField returns the field with the field ID of name, or else with the field number of name,
+// in the schema of the file being decoded.
+func (o *SchemaOptions) findWriterField(desc protoreflect.MessageDescriptor, name string) (protoreflect.FieldDescriptor, bool) {
+	wf, ok := o.writerFields[o.avroFullName(desc)][name]
+	if !ok {
+		return nil, false
+	}
+	fields := o.recordFields(desc)
+	if wf.id != 0 {
+		for _, fd := range fields {
+			if o.fieldID(fd) == wf.id {
+				return fd, true
+			}
+		}
+	}
+	if wf.number != 0 {
+		for _, fd := range fields {
+			if fd.Number() == wf.number {
+				return fd, true
+			}
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,154 @@
+package protoavro
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/linkedin/goavro/v2"
+	"go.einride.tech/protobuf-avro/avro"
+	examplev1 "go.einride.tech/protobuf-avro/internal/examples/proto/gen/einride/avro/example/v1"
+	"google.golang.org/genproto/googleapis/example/library/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+	"gotest.tools/v3/assert"
+)
+
+func Test_FieldIDs(t *testing.T) {
+	opts := SchemaOptions{OmitRootElement: true, OmitNullArray: true, FieldIDs: true}
+
+	t.Run("list", func(t *testing.T) {
+		schema, err := opts.InferSchema((&examplev1.ExampleList{}).ProtoReflect().Descriptor())
+		assert.NilError(t, err)
+		field := schema.(avro.Record).Fields[2]
+		assert.Equal(t, "enum_list", field.Name)
+		assert.Equal(t, 3, field.FieldID)
+		assert.Equal(t, 1298053828, field.Type.(avro.Array).ElementID)
+		assert.DeepEqual(t, map[string]interface{}{"proto.number": 3}, field.Props)
+	})
+
+	t.Run("map", func(t *testing.T) {
+		schema, err := opts.InferSchema((&examplev1.ExampleMap{}).ProtoReflect().Descriptor())
+		assert.NilError(t, err)
+		field := schema.(avro.Record).Fields[3]
+		assert.Equal(t, "int32_to_string", field.Name)
+		assert.Equal(t, 4, field.FieldID)
+		array := field.Type.(avro.Union)[1].(avro.Array)
+		assert.Equal(t, avro.MapLogicalType, array.LogicalType)
+		entry := array.Items.(avro.Record)
+		assert.Equal(t, 1415285071, entry.Fields[0].FieldID)
+		assert.Equal(t, 1432062690, entry.Fields[1].FieldID)
+		assert.Equal(t, 1415285071, array.KeyID)
+		assert.Equal(t, 1432062690, array.ValueID)
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		// the IDs of fields of nested messages only depend on the message and the field number
+		schema, err := opts.InferSchema((&library.ListBooksResponse{}).ProtoReflect().Descriptor())
+		assert.NilError(t, err)
+		field := schema.(avro.Record).Fields[0]
+		assert.Equal(t, "books", field.Name)
+		assert.Equal(t, 1, field.FieldID)
+		book := field.Type.(avro.Array).Items.(avro.Record)
+		assert.Equal(t, "name", book.Fields[0].Name)
+		assert.Equal(t, 1953513706, book.Fields[0].FieldID)
+		assert.Equal(t, "title", book.Fields[2].Name)
+		assert.Equal(t, 1919958468, book.Fields[2].FieldID)
+	})
+
+	t.Run("unique", func(t *testing.T) {
+		for _, msg := range []proto.Message{
+			&library.ListBooksResponse{},
+			&examplev1.ExampleMap{},
+			&examplev1.ExampleList{},
+		} {
+			schema, err := opts.InferSchema(msg.ProtoReflect().Descriptor())
+			assert.NilError(t, err)
+			b, err := json.Marshal(schema)
+			assert.NilError(t, err)
+			var v interface{}
+			assert.NilError(t, json.Unmarshal(b, &v))
+			seen := make(map[float64]string)
+			collectIDs(t, v, seen)
+			assert.Assert(t, len(seen) > 0)
+		}
+	})
+
+	t.Run("roundtrip", func(t *testing.T) {
+		msg := &examplev1.ExampleMap{
+			StringToString: map[string]string{"a": "b"},
+			Int32ToString:  map[int32]string{1: "c"},
+		}
+		var b bytes.Buffer
+		marshaler, err := opts.NewMarshaler(msg.ProtoReflect().Descriptor(), &b)
+		assert.NilError(t, err)
+		assert.NilError(t, marshaler.Marshal(msg))
+		unmarshaler, err := opts.NewUnmarshaler(&b)
+		assert.NilError(t, err)
+		assert.Assert(t, unmarshaler.Scan())
+		got := &examplev1.ExampleMap{}
+		assert.NilError(t, unmarshaler.Unmarshal(got))
+		assert.DeepEqual(t, msg, got, protocmp.Transform())
+	})
+
+	// written before name was renamed from id, and author and title were swapped
+	for _, attribute := range []string{"field-id", "proto.number"} {
+		attribute := attribute
+		t.Run("renamed fields by "+attribute, func(t *testing.T) {
+			writerSchema := fmt.Sprintf(`{
+				"type": "record",
+				"name": "Book",
+				"namespace": "google.example.library.v1",
+				"fields": [
+					{"name": "id", "%[1]s": 1, "type": ["null", "string"], "default": null},
+					{"name": "title", "%[1]s": 2, "type": ["null", "string"], "default": null},
+					{"name": "author", "%[1]s": 3, "type": ["null", "string"], "default": null}
+				]
+			}`, attribute)
+			var b bytes.Buffer
+			w, err := goavro.NewOCFWriter(goavro.OCFConfig{W: &b, Schema: writerSchema})
+			assert.NilError(t, err)
+			assert.NilError(t, w.Append([]interface{}{map[string]interface{}{
+				"id":     map[string]interface{}{"string": "shelves/1/books/1"},
+				"title":  map[string]interface{}{"string": "J. K. Rowling"},
+				"author": map[string]interface{}{"string": "Harry Potter"},
+			}}))
+			unmarshaler, err := opts.NewUnmarshaler(&b)
+			assert.NilError(t, err)
+			assert.Assert(t, unmarshaler.Scan())
+			got := &library.Book{}
+			assert.NilError(t, unmarshaler.Unmarshal(got))
+			assert.DeepEqual(t, &library.Book{
+				Name:   "shelves/1/books/1",
+				Author: "J. K. Rowling",
+				Title:  "Harry Potter",
+			}, got, protocmp.Transform())
+		})
+	}
+}
+
+// collectIDs collects the field and element IDs in the JSON encoded schema v, and fails on duplicates.
+// Key and value IDs are the field IDs of the fields of map entries.
+func collectIDs(t *testing.T, v interface{}, seen map[float64]string) {
+	t.Helper()
+	switch s := v.(type) {
+	case []interface{}:
+		for _, el := range s {
+			collectIDs(t, el, seen)
+		}
+	case map[string]interface{}:
+		for _, attribute := range []string{"field-id", "element-id"} {
+			id, ok := s[attribute].(float64)
+			if !ok {
+				continue
+			}
+			previous, ok := seen[id]
+			assert.Assert(t, !ok, "%s %v is also the %s", attribute, id, previous)
+			seen[id] = attribute
+		}
+		for _, value := range s {
+			collectIDs(t, value, seen)
+		}
+	}
+}
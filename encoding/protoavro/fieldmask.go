@@ -0,0 +1,99 @@
+package protoavro
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// fieldMask is a tree of the fields selected by a field mask.
+// A nil fieldMask selects all fields.
+type fieldMask map[protoreflect.Name]fieldMask
+
+// newFieldMask returns the tree of fields selected by fm, relative to the message desc.
+func newFieldMask(fm *fieldmaskpb.FieldMask, desc protoreflect.MessageDescriptor) (fieldMask, error) {
+	if len(fm.GetPaths()) == 0 {
+		return nil, nil
+	}
+	mask := fieldMask{}
+	for _, path := range fm.GetPaths() {
+		node := mask
+		parts := strings.Split(path, ".")
+		for i, part := range parts {
+			name := protoreflect.Name(part)
+			child, ok := node[name]
+			if ok && child == nil {
+				// field already selected in its entirety
+				break
+			}
+			if i == len(parts)-1 {
+				node[name] = nil
+				break
+			}
+			if !ok {
+				child = fieldMask{}
+				node[name] = child
+			}
+			node = child
+		}
+	}
+	if err := mask.validate(desc); err != nil {
+		return nil, fmt.Errorf("field mask: %w", err)
+	}
+	return mask, nil
+}
+
+func (m fieldMask) validate(desc protoreflect.MessageDescriptor) error {
+	for name, child := range m {
+		field := desc.Fields().ByName(name)
+		if field == nil {
+			return fmt.Errorf("unknown field '%s' in message '%s'", name, desc.FullName())
+		}
+		if child == nil {
+			continue
+		}
+		if field.Message() == nil || field.IsMap() || isWKT(field.Message().FullName()) {
+			return fmt.Errorf("field '%s' in message '%s' cannot be partially selected", name, desc.FullName())
+		}
+		if err := child.validate(field.Message()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sub returns whether the field is selected, and the mask for its sub fields.
+func (m fieldMask) sub(field protoreflect.FieldDescriptor) (fieldMask, bool) {
+	if m == nil {
+		return nil, true
+	}
+	child, ok := m[field.Name()]
+	return child, ok
+}
+
+// String returns a canonical representation of the mask, used to detect
+// when the same message is projected in different ways.
+func (m fieldMask) String() string {
+	if m == nil {
+		return ""
+	}
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, string(name))
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	b.WriteString("(")
+	for i, name := range names {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		b.WriteString(name)
+		b.WriteString(m[protoreflect.Name(name)].String())
+	}
+	b.WriteString(")")
+	return b.String()
+}
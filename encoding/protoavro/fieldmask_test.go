@@ -0,0 +1,119 @@
+package protoavro
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/linkedin/goavro/v2"
+	"go.einride.tech/protobuf-avro/avro"
+	examplev1 "go.einride.tech/protobuf-avro/internal/examples/proto/gen/einride/avro/example/v1"
+	"google.golang.org/genproto/googleapis/example/library/v1"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"gotest.tools/v3/assert"
+)
+
+func Test_FieldMask(t *testing.T) {
+	opts := SchemaOptions{
+		OmitRootElement: true,
+		FieldMask: &fieldmaskpb.FieldMask{
+			Paths: []string{"book.name", "book.title"},
+		},
+	}
+	msg := &library.UpdateBookRequest{
+		Book: &library.Book{
+			Name:   "books/1",
+			Author: "J. K. Rowling",
+			Title:  "Harry Potter",
+			Read:   true,
+		},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"title"}},
+	}
+
+	schema, err := opts.InferSchema(msg.ProtoReflect().Descriptor())
+	assert.NilError(t, err)
+	assert.DeepEqual(t, avro.Record{
+		Type:      avro.RecordType,
+		Name:      "UpdateBookRequest",
+		Namespace: "google.example.library.v1",
+		Fields: []avro.Field{
+			{
+				Name: "book",
+				Type: avro.Nullable(avro.Record{
+					Type:      avro.RecordType,
+					Name:      "Book",
+					Namespace: "google.example.library.v1",
+					Fields: []avro.Field{
+						{Name: "name", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(1)},
+						{Name: "title", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(3)},
+					},
+				}),
+				Default: avro.NullDefault,
+				Props:   numberProps(1),
+			},
+		},
+	}, schema)
+
+	got, err := opts.encodeJSON(msg)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, map[string]interface{}{
+		"book": map[string]interface{}{
+			"google.example.library.v1.Book": map[string]interface{}{
+				"name":  map[string]interface{}{"string": "books/1"},
+				"title": map[string]interface{}{"string": "Harry Potter"},
+			},
+		},
+	}, got)
+
+	// assert that it matches schema
+	schemaBytes, err := json.Marshal(schema)
+	assert.NilError(t, err)
+	codec, err := goavro.NewCodec(string(schemaBytes))
+	assert.NilError(t, err)
+	_, err = codec.BinaryFromNative(nil, got)
+	assert.NilError(t, err)
+
+	// decoding records written without a field mask only decodes selected paths
+	full, err := SchemaOptions{OmitRootElement: true}.encodeJSON(msg)
+	assert.NilError(t, err)
+	var decoded library.UpdateBookRequest
+	assert.NilError(t, opts.decodeJSON(full, &decoded))
+	assert.DeepEqual(t, &library.UpdateBookRequest{
+		Book: &library.Book{
+			Name:  "books/1",
+			Title: "Harry Potter",
+		},
+	}, &decoded, protocmp.Transform())
+}
+
+func Test_FieldMaskErr(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		paths       []string
+		errContains string
+	}{
+		{
+			name:        "unknown field",
+			paths:       []string{"book.publisher"},
+			errContains: "field mask: unknown field 'publisher' in message 'google.example.library.v1.Book'",
+		},
+		{
+			name:        "sub field of scalar",
+			paths:       []string{"book.name.first"},
+			errContains: "field mask: field 'name' in message 'google.example.library.v1.Book' cannot be partially selected",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			opts := SchemaOptions{FieldMask: &fieldmaskpb.FieldMask{Paths: tt.paths}}
+			_, err := opts.InferSchema((&library.UpdateBookRequest{}).ProtoReflect().Descriptor())
+			assert.ErrorContains(t, err, tt.errContains)
+		})
+	}
+
+	t.Run("conflicting masks", func(t *testing.T) {
+		opts := SchemaOptions{FieldMask: &fieldmaskpb.FieldMask{Paths: []string{"left.value", "right"}}}
+		_, err := opts.InferSchema((&examplev1.ExampleSeen{}).ProtoReflect().Descriptor())
+		assert.ErrorContains(t, err, "message 'einride.avro.example.v1.ExampleData' is selected by conflicting field masks")
+	})
+}
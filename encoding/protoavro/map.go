@@ -9,14 +9,21 @@ import (
 )
 
 func (s schemaInferrer) inferMapSchema(field protoreflect.FieldDescriptor, recursiveIndex int) (avro.Schema, error) {
-	fieldKind, err := s.inferFieldKind(field, recursiveIndex)
+	fieldKind, err := s.inferFieldKind(field, nil, recursiveIndex)
 	if err != nil {
 		return nil, err
 	}
-	return avro.Nullable(avro.Array{
+	array := avro.Array{
 		Type:  avro.ArrayType,
 		Items: fieldKind,
-	}), nil
+	}
+	if entry, ok := fieldKind.(avro.Record); ok && s.opts.FieldIDs {
+		// the key and value IDs are the field IDs of the key and value fields of the entries
+		array.LogicalType = avro.MapLogicalType
+		array.KeyID = entry.Fields[0].FieldID
+		array.ValueID = entry.Fields[1].FieldID
+	}
+	return avro.Nullable(array), nil
 }
 
 func (o *SchemaOptions) encodeMap(
@@ -43,11 +50,11 @@ func (o *SchemaOptions) encodeMap(
 	keyField := field.MapKey()
 	for _, key := range keys {
 		value := m.Get(key)
-		keyValue, err := o.fieldKindJSON(keyField, key.Value(), recursiveIndex, true)
+		keyValue, err := o.fieldKindJSON(keyField, key.Value(), nil, recursiveIndex, true)
 		if err != nil {
 			return nil, err
 		}
-		valueValue, err := o.fieldKindJSON(valueField, value, recursiveIndex, true)
+		valueValue, err := o.fieldKindJSON(valueField, value, nil, recursiveIndex, true)
 		if err != nil {
 			return nil, err
 		}
@@ -81,11 +88,11 @@ func (o SchemaOptions) decodeMapEntries(data []interface{}, f protoreflect.Field
 		if !ok {
 			return fmt.Errorf("missing 'value' in map entry for '%s'", f.Name())
 		}
-		keyValue, err := o.decodeFieldKind(keyData, protoreflect.Value{}, f.MapKey())
+		keyValue, err := o.decodeFieldKind(keyData, protoreflect.Value{}, f.MapKey(), nil)
 		if err != nil {
 			return err
 		}
-		valueValue, err := o.decodeFieldKind(valueData, mp.NewValue(), f.MapValue())
+		valueValue, err := o.decodeFieldKind(valueData, mp.NewValue(), f.MapValue(), nil)
 		if err != nil {
 			return err
 		}
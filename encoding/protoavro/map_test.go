@@ -30,8 +30,8 @@ func Test_MapSchema(t *testing.T) {
 					Name:      "StringToStringEntry",
 					Namespace: "einride.avro.example.v1.ExampleMap",
 					Fields: []avro.Field{
-						{Name: "key", Type: avro.Nullable(avro.String())},
-						{Name: "value", Type: avro.Nullable(avro.String())},
+						{Name: "key", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(1)},
+						{Name: "value", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(2)},
 					},
 				},
 			}),
@@ -47,7 +47,7 @@ func Test_MapSchema(t *testing.T) {
 					Name:      "StringToNestedEntry",
 					Namespace: "einride.avro.example.v1.ExampleMap",
 					Fields: []avro.Field{
-						{Name: "key", Type: avro.Nullable(avro.String())},
+						{Name: "key", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(1)},
 						{
 							Name: "value",
 							Type: avro.Nullable(avro.Record{
@@ -64,14 +64,18 @@ func Test_MapSchema(t *testing.T) {
 												Name:      "StringToStringEntry",
 												Namespace: "einride.avro.example.v1.ExampleMap.Nested",
 												Fields: []avro.Field{
-													{Name: "key", Type: avro.Nullable(avro.String())},
-													{Name: "value", Type: avro.Nullable(avro.String())},
+													{Name: "key", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(1)},
+													{Name: "value", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(2)},
 												},
 											},
 										}),
+										Default: avro.NullDefault,
+										Props:   numberProps(1),
 									},
 								},
 							}),
+							Default: avro.NullDefault,
+							Props:   numberProps(2),
 						},
 					},
 				},
@@ -88,7 +92,7 @@ func Test_MapSchema(t *testing.T) {
 					Name:      "StringToEnumEntry",
 					Namespace: "einride.avro.example.v1.ExampleMap",
 					Fields: []avro.Field{
-						{Name: "key", Type: avro.Nullable(avro.String())},
+						{Name: "key", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(1)},
 						{
 							Name: "value", Type: avro.Nullable(avro.Enum{
 								Type:      avro.EnumType,
@@ -99,7 +103,10 @@ func Test_MapSchema(t *testing.T) {
 									"ENUM_VALUE1",
 									"ENUM_VALUE2",
 								},
+								Default: "ENUM_UNSPECIFIED",
 							}),
+							Default: avro.NullDefault,
+							Props:   numberProps(2),
 						},
 					},
 				},
@@ -116,8 +123,8 @@ func Test_MapSchema(t *testing.T) {
 					Name:      "Int32ToStringEntry",
 					Namespace: "einride.avro.example.v1.ExampleMap",
 					Fields: []avro.Field{
-						{Name: "key", Type: avro.Nullable(avro.Integer())},
-						{Name: "value", Type: avro.Nullable(avro.String())},
+						{Name: "key", Type: avro.Nullable(avro.Integer()), Default: avro.NullDefault, Props: numberProps(1)},
+						{Name: "value", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(2)},
 					},
 				},
 			}),
@@ -133,8 +140,8 @@ func Test_MapSchema(t *testing.T) {
 					Name:      "Int64ToStringEntry",
 					Namespace: "einride.avro.example.v1.ExampleMap",
 					Fields: []avro.Field{
-						{Name: "key", Type: avro.Nullable(avro.Long())},
-						{Name: "value", Type: avro.Nullable(avro.String())},
+						{Name: "key", Type: avro.Nullable(avro.Long()), Default: avro.NullDefault, Props: numberProps(1)},
+						{Name: "value", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(2)},
 					},
 				},
 			}),
@@ -150,8 +157,8 @@ func Test_MapSchema(t *testing.T) {
 					Name:      "Uint32ToStringEntry",
 					Namespace: "einride.avro.example.v1.ExampleMap",
 					Fields: []avro.Field{
-						{Name: "key", Type: avro.Nullable(avro.Integer())},
-						{Name: "value", Type: avro.Nullable(avro.String())},
+						{Name: "key", Type: avro.Nullable(avro.Integer()), Default: avro.NullDefault, Props: numberProps(1)},
+						{Name: "value", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(2)},
 					},
 				},
 			}),
@@ -167,8 +174,8 @@ func Test_MapSchema(t *testing.T) {
 					Name:      "BoolToStringEntry",
 					Namespace: "einride.avro.example.v1.ExampleMap",
 					Fields: []avro.Field{
-						{Name: "key", Type: avro.Nullable(avro.Boolean())},
-						{Name: "value", Type: avro.Nullable(avro.String())},
+						{Name: "key", Type: avro.Nullable(avro.Boolean()), Default: avro.NullDefault, Props: numberProps(1)},
+						{Name: "value", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(2)},
 					},
 				},
 			}),
@@ -6,6 +6,7 @@ import (
 	"io"
 
 	"github.com/linkedin/goavro/v2"
+	"go.einride.tech/protobuf-avro/avro"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
 )
@@ -23,6 +24,9 @@ func (o SchemaOptions) NewMarshaler(descriptor protoreflect.MessageDescriptor, w
 	if err != nil {
 		return nil, fmt.Errorf("infer schema: %w", err)
 	}
+	if err := avro.Validate(schema); err != nil {
+		return nil, fmt.Errorf("validate schema: %w", err)
+	}
 	schemaBytes, err := json.Marshal(schema)
 	if err != nil {
 		return nil, fmt.Errorf("json marshal schema: %w", err)
@@ -34,26 +38,38 @@ func (o SchemaOptions) NewMarshaler(descriptor protoreflect.MessageDescriptor, w
 	if err != nil {
 		return nil, fmt.Errorf("new ocf writer: %w", err)
 	}
-	return &Marshaler{w: w, desc: descriptor, opts: o}, nil
+	return &Marshaler{
+		w:         w,
+		desc:      descriptor,
+		opts:      o,
+		schema:    schema,
+		validator: newDatumValidator(schema),
+	}, nil
 }
 
 // Marshaler encodes and writes Avro binary encoded messages.
 type Marshaler struct {
-	opts SchemaOptions
-	desc protoreflect.MessageDescriptor
-	w    *goavro.OCFWriter
+	opts      SchemaOptions
+	desc      protoreflect.MessageDescriptor
+	schema    avro.Schema
+	validator datumValidator
+	w         *goavro.OCFWriter
 }
 
 // Marshal encodes and writes messages to the writer.
 func (m *Marshaler) Marshal(messages ...proto.Message) error {
-	data := make([]interface{}, 0, len(messages))
 	for _, message := range messages {
-		a := message.ProtoReflect().Descriptor().FullName()
-		b := m.desc.FullName()
-		if a != b {
+		if a, b := m.desc.FullName(), message.ProtoReflect().Descriptor().FullName(); a != b {
 			return fmt.Errorf("expected message '%s' but got '%s'", a, b)
 		}
+	}
+	return m.marshal(messages)
+}
 
+// marshal encodes and writes messages that are known to be of the marshaler's type.
+func (m *Marshaler) marshal(messages []proto.Message) error {
+	data := make([]interface{}, 0, len(messages))
+	for _, message := range messages {
 		m, err := m.opts.encodeJSON(message)
 		if err != nil {
 			return fmt.Errorf("encode json: %w", err)
@@ -67,25 +83,73 @@ func (m *Marshaler) Marshal(messages ...proto.Message) error {
 }
 
 // Encode encodes the message.
-func (o SchemaOptions) Encode(message proto.Message) (interface{}, error) {
+func (o SchemaOptions) Encode(message proto.Message) (EncodedDatum, error) {
 	encJSON, err := o.encodeJSON(message)
 	if err != nil {
-		return nil, fmt.Errorf("encode json: %w", err)
+		return EncodedDatum{}, fmt.Errorf("encode json: %w", err)
 	}
 
-	return encJSON, nil
+	return EncodedDatum{desc: message.ProtoReflect().Descriptor(), native: encJSON}, nil
+}
+
+// AppendEncoded writes datums, encoded with SchemaOptions.Encode, to the writer.
+// Datums must be encoded from messages of the marshaler's type and match its schema.
+func (m *Marshaler) AppendEncoded(datums ...EncodedDatum) error {
+	data := make([]interface{}, 0, len(datums))
+	for _, datum := range datums {
+		if datum.desc == nil {
+			return fmt.Errorf("datum not encoded by SchemaOptions.Encode")
+		}
+		if a, b := datum.desc.FullName(), m.desc.FullName(); a != b {
+			return fmt.Errorf("expected datum for message '%s' but got '%s'", b, a)
+		}
+		if err := m.Validate(datum.native); err != nil {
+			return err
+		}
+		data = append(data, datum.native)
+	}
+	if err := m.w.Append(data); err != nil {
+		return fmt.Errorf("append: %w", err)
+	}
+	return nil
 }
 
 // Append writes the messages to the writer.
+// Each datum is validated against the schema before being written.
+//
+// Deprecated: Use AppendEncoded.
 func (m *Marshaler) Append(messages interface{}) error {
 	data, ok := messages.([]interface{})
 	if !ok {
 		// If messages is not a slice, make it a slice.
 		data = append(data, messages)
 	}
+	natives := make([]interface{}, 0, len(data))
+	for _, datum := range data {
+		if encoded, ok := datum.(EncodedDatum); ok {
+			datum = encoded.native
+		}
+		if err := m.Validate(datum); err != nil {
+			return err
+		}
+		natives = append(natives, datum)
+	}
 
-	if err := m.w.Append(data); err != nil {
+	if err := m.w.Append(natives); err != nil {
 		return fmt.Errorf("append: %w", err)
 	}
 	return nil
 }
+
+// Validate checks that datum matches the schema of the marshaler.
+// Datum is either an EncodedDatum or a value in the native Go form used by goavro.
+// The returned error reports the path of the first mismatching field.
+func (m *Marshaler) Validate(datum interface{}) error {
+	if encoded, ok := datum.(EncodedDatum); ok {
+		datum = encoded.native
+	}
+	if err := m.validator.validate(m.schema, datum, string(m.desc.Name())); err != nil {
+		return fmt.Errorf("validate: %w", err)
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package protoavro_test
 
 import (
 	"bytes"
+	"io"
 	"testing"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"google.golang.org/genproto/googleapis/type/timeofday"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/dynamicpb"
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"gotest.tools/v3/assert"
@@ -111,3 +113,218 @@ func Test_MarshalSymmetric(t *testing.T) {
 		})
 	}
 }
+
+func Test_MarshalAppendEncoded(t *testing.T) {
+	msgs := []*library.Book{
+		{
+			Name:   "shelves/1/books/1",
+			Title:  "Harry Potter",
+			Author: "J. K. Rowling",
+		},
+		{
+			Name:   "shelves/1/books/2",
+			Title:  "Lord of the Rings",
+			Author: "J. R. R. Tolkien",
+		},
+	}
+
+	var b bytes.Buffer
+
+	// append encoded messages
+	marshaller, err := protoavro.NewMarshaler(msgs[0].ProtoReflect().Descriptor(), &b)
+	assert.NilError(t, err)
+	for _, msg := range msgs {
+		datum, err := protoavro.SchemaOptions{}.Encode(msg)
+		assert.NilError(t, err)
+		assert.NilError(t, marshaller.AppendEncoded(datum))
+	}
+
+	// unmarshal messages
+	unmarshaler, err := protoavro.NewUnmarshaler(&b)
+	assert.NilError(t, err)
+	got := make([]*library.Book, 0, 2)
+	for unmarshaler.Scan() {
+		var msg library.Book
+		assert.NilError(t, unmarshaler.Unmarshal(&msg))
+		got = append(got, &msg)
+	}
+
+	assert.DeepEqual(t, msgs, got, protocmp.Transform())
+}
+
+func Test_MarshalErr(t *testing.T) {
+	var b bytes.Buffer
+	marshaller, err := protoavro.NewMarshaler((&library.Book{}).ProtoReflect().Descriptor(), &b)
+	assert.NilError(t, err)
+	assert.Error(
+		t,
+		marshaller.Marshal(&library.Shelf{Name: "shelves/1"}),
+		"expected message 'google.example.library.v1.Book' but got 'google.example.library.v1.Shelf'",
+	)
+}
+
+func Test_MarshalAppendEncodedErr(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		opts        protoavro.SchemaOptions
+		msg         proto.Message
+		errContains string
+	}{
+		{
+			name:        "other message",
+			msg:         &library.Shelf{Name: "shelves/1"},
+			errContains: "expected datum for message 'google.example.library.v1.Book' but got 'google.example.library.v1.Shelf'",
+		},
+		{
+			name:        "other options",
+			opts:        protoavro.SchemaOptions{OmitRootElement: true},
+			msg:         &library.Book{Name: "shelves/1/books/1"},
+			errContains: "validate: Book: expected union value as map with a single key",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var b bytes.Buffer
+			marshaller, err := protoavro.NewMarshaler((&library.Book{}).ProtoReflect().Descriptor(), &b)
+			assert.NilError(t, err)
+			datum, err := tt.opts.Encode(tt.msg)
+			assert.NilError(t, err)
+			assert.ErrorContains(t, marshaller.AppendEncoded(datum), tt.errContains)
+		})
+	}
+}
+
+func Test_MarshalValidate(t *testing.T) {
+	var b bytes.Buffer
+	marshaller, err := protoavro.NewMarshaler((&library.Book{}).ProtoReflect().Descriptor(), &b)
+	assert.NilError(t, err)
+	for _, tt := range []struct {
+		name        string
+		datum       interface{}
+		errContains string
+	}{
+		{
+			name: "valid",
+			datum: map[string]interface{}{
+				"google.example.library.v1.Book": map[string]interface{}{
+					"name":   map[string]interface{}{"string": "shelves/1/books/1"},
+					"author": nil,
+					"title":  nil,
+					"read":   map[string]interface{}{"boolean": true},
+				},
+			},
+		},
+		{
+			name: "wrong type",
+			datum: map[string]interface{}{
+				"google.example.library.v1.Book": map[string]interface{}{
+					"name":   map[string]interface{}{"string": "shelves/1/books/1"},
+					"author": nil,
+					"title":  nil,
+					"read":   map[string]interface{}{"boolean": "yes"},
+				},
+			},
+			errContains: "Book.read: expected boolean, got string",
+		},
+		{
+			name: "wrong union branch",
+			datum: map[string]interface{}{
+				"google.example.library.v1.Book": map[string]interface{}{
+					"name":   map[string]interface{}{"int": 1},
+					"author": nil,
+					"title":  nil,
+					"read":   nil,
+				},
+			},
+			errContains: "Book.name: unexpected union branch 'int'",
+		},
+		{
+			name: "missing field",
+			datum: map[string]interface{}{
+				"google.example.library.v1.Book": map[string]interface{}{
+					"name": nil,
+				},
+			},
+			errContains: "Book.author: missing field",
+		},
+		{
+			name: "unexpected field",
+			datum: map[string]interface{}{
+				"google.example.library.v1.Book": map[string]interface{}{
+					"name":      nil,
+					"author":    nil,
+					"title":     nil,
+					"read":      nil,
+					"publisher": nil,
+				},
+			},
+			errContains: "Book.publisher: unexpected field",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := marshaller.Validate(tt.datum)
+			if tt.errContains == "" {
+				assert.NilError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.errContains)
+		})
+	}
+}
+
+func Test_WriterReader(t *testing.T) {
+	msgs := []*library.Book{
+		{
+			Name:   "shelves/1/books/1",
+			Title:  "Harry Potter",
+			Author: "J. K. Rowling",
+		},
+		{
+			Name:   "shelves/1/books/2",
+			Title:  "Lord of the Rings",
+			Author: "J. R. R. Tolkien",
+		},
+	}
+
+	var b bytes.Buffer
+
+	// write messages
+	writer, err := protoavro.NewWriter[*library.Book](&b, protoavro.SchemaOptions{})
+	assert.NilError(t, err)
+	assert.NilError(t, writer.Write(msgs...))
+
+	// read messages
+	reader, err := protoavro.NewReader[*library.Book](bytes.NewReader(b.Bytes()), protoavro.SchemaOptions{})
+	assert.NilError(t, err)
+	got := make([]*library.Book, 0, 2)
+	reader.All()(func(msg *library.Book, err error) bool {
+		assert.NilError(t, err)
+		got = append(got, msg)
+		return true
+	})
+	assert.DeepEqual(t, msgs, got, protocmp.Transform())
+
+	// read messages one by one
+	reader, err = protoavro.NewReader[*library.Book](bytes.NewReader(b.Bytes()), protoavro.SchemaOptions{})
+	assert.NilError(t, err)
+	for _, expected := range msgs {
+		msg, err := reader.Next()
+		assert.NilError(t, err)
+		assert.DeepEqual(t, expected, msg, protocmp.Transform())
+	}
+	_, err = reader.Next()
+	assert.Equal(t, err, io.EOF)
+}
+
+func Test_WriterReaderMessageType(t *testing.T) {
+	var b bytes.Buffer
+	_, err := protoavro.NewWriter[proto.Message](&b, protoavro.SchemaOptions{})
+	assert.Error(t, err, "message type of protoreflect.ProtoMessage: not a concrete message type")
+	_, err = protoavro.NewWriter[*dynamicpb.Message](&b, protoavro.SchemaOptions{})
+	assert.Error(t, err, "message type of *dynamicpb.Message: no descriptor for the zero value")
+	_, err = protoavro.NewReader[proto.Message](&b, protoavro.SchemaOptions{})
+	assert.Error(t, err, "message type of protoreflect.ProtoMessage: not a concrete message type")
+	_, err = protoavro.NewReader[*library.Book](&b, protoavro.SchemaOptions{})
+	assert.ErrorContains(t, err, "new unmarshaler: ")
+}
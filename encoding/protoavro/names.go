@@ -0,0 +1,264 @@
+package protoavro
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// avroNameRegexp matches valid Avro names of records, fields, enums, enum symbols and fixed types.
+// See: https://avro.apache.org/docs/current/specification/#names
+var avroNameRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+func checkName(name string) error {
+	if !avroNameRegexp.MatchString(name) {
+		return fmt.Errorf("invalid Avro name '%s'", name)
+	}
+	return nil
+}
+
+func checkNamespace(namespace string) error {
+	if namespace == "" {
+		return nil
+	}
+	for _, part := range strings.Split(namespace, ".") {
+		if err := checkName(part); err != nil {
+			return fmt.Errorf("namespace '%s': %w", namespace, err)
+		}
+	}
+	return nil
+}
+
+// sanitizeName replaces the characters of name that are not allowed in Avro names with
+// underscores, and prefixes it with an underscore when it does not start with a letter or underscore.
+func sanitizeName(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		switch {
+		case r == '_' || r >= 'A' && r <= 'Z' || r >= 'a' && r <= 'z':
+			b.WriteRune(r)
+		case r >= '0' && r <= '9':
+			if i == 0 {
+				b.WriteRune('_')
+			}
+			b.WriteRune(r)
+		default:
+			b.WriteRune('_')
+		}
+	}
+	if b.Len() == 0 {
+		return "_"
+	}
+	return b.String()
+}
+
+// sanitizeNames sanitizes each name, and makes the result unique by suffixing
+// names that collide with earlier names with an underscore and a counter.
+// The sanitized names map back to the original names by position.
+func sanitizeNames(names []string) []string {
+	result := make([]string, 0, len(names))
+	seen := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		sanitized := sanitizeName(name)
+		candidate := sanitized
+		for i := 2; ; i++ {
+			if _, ok := seen[candidate]; !ok {
+				break
+			}
+			candidate = sanitized + "_" + strconv.Itoa(i)
+		}
+		seen[candidate] = struct{}{}
+		result = append(result, candidate)
+	}
+	return result
+}
+
+// avroName returns the Avro name of a message, enum or fixed type.
+func (o SchemaOptions) avroName(desc protoreflect.Descriptor) string {
+	if o.SanitizeNames {
+		return sanitizeName(string(desc.Name()))
+	}
+	return string(desc.Name())
+}
+
+// avroNamespace returns the Avro namespace of a message, enum or fixed type.
+func (o SchemaOptions) avroNamespace(desc protoreflect.Descriptor) string {
+	ns := namespace(desc)
+	if !o.SanitizeNames || ns == "" {
+		return ns
+	}
+	parts := strings.Split(ns, ".")
+	for i, part := range parts {
+		parts[i] = sanitizeName(part)
+	}
+	return strings.Join(parts, ".")
+}
+
+// avroFullName returns the Avro full name of a message, enum or fixed type,
+// which is also the key of its branch in union values.
+func (o SchemaOptions) avroFullName(desc protoreflect.Descriptor) string {
+	return fullName(o.avroNamespace(desc), o.avroName(desc))
+}
+
+// recordNames are the Avro names of the fields of the record of a message.
+type recordNames struct {
+	// names are the names of the fields by index in recordFields.
+	names []string
+	// fields are the fields by name.
+	fields map[string]protoreflect.FieldDescriptor
+	// extensions is the number of extensions of the message in SchemaOptions.Extensions
+	// that the names were computed with.
+	extensions int
+}
+
+type recordNamesKey struct {
+	desc       protoreflect.MessageDescriptor
+	extensions *protoregistry.Types
+	sanitize   bool
+}
+
+// recordNamesCache caches recordNames by recordNamesKey. The names are looked up for every
+// encoded and decoded message, and sanitizing them depends on all fields of the message.
+var recordNamesCache sync.Map
+
+// fieldNames returns the Avro names of the fields of the record of the message, by index in recordFields.
+// Extensions are named by their full names, with dots replaced by underscores.
+func (o SchemaOptions) fieldNames(desc protoreflect.MessageDescriptor) []string {
+	return o.recordNames(desc).names
+}
+
+// recordNames returns the Avro names of the fields of the record of the message, computed once
+// per message, and again when more extensions of the message are registered.
+func (o SchemaOptions) recordNames(desc protoreflect.MessageDescriptor) *recordNames {
+	key := recordNamesKey{desc: desc, extensions: o.Extensions, sanitize: o.SanitizeNames}
+	var extensions int
+	if o.Extensions != nil {
+		extensions = o.Extensions.NumExtensionsByMessage(desc.FullName())
+	}
+	if cached, ok := recordNamesCache.Load(key); ok && cached.(*recordNames).extensions == extensions {
+		return cached.(*recordNames)
+	}
+	fields := o.recordFields(desc)
+	names := make([]string, 0, len(fields))
+	for _, field := range fields {
+		names = append(names, protoFieldName(field))
+	}
+	if o.SanitizeNames {
+		names = sanitizeNames(names)
+	}
+	result := &recordNames{
+		names:      names,
+		fields:     make(map[string]protoreflect.FieldDescriptor, len(fields)),
+		extensions: extensions,
+	}
+	for i, field := range fields {
+		result.fields[names[i]] = field
+	}
+	recordNamesCache.Store(key, result)
+	return result
+}
+
+// fieldName returns the Avro name of the field at index i in recordFields, where sanitized
+// are the names of fieldNames when names are sanitized. Without sanitization, the name is
+// derived from the descriptor, so that fieldNames is not called for every record.
+func (o SchemaOptions) fieldName(field protoreflect.FieldDescriptor, sanitized []string, i int) string {
+	if o.SanitizeNames {
+		return sanitized[i]
+	}
+	return protoFieldName(field)
+}
+
+func protoFieldName(field protoreflect.FieldDescriptor) string {
+	if field.IsExtension() {
+		return flattenedFieldName(string(field.FullName()))
+	}
+	return string(field.Name())
+}
+
+// sanitizedSymbols are the sanitized Avro symbols of the values of an enum.
+type sanitizedSymbols struct {
+	// symbols are the symbols by value index.
+	symbols []string
+	// values are the values by symbol.
+	values map[string]protoreflect.EnumValueDescriptor
+}
+
+// sanitizedSymbolsCache caches sanitizedSymbols by enum descriptor. The symbols are looked up
+// for every encoded and decoded value, and sanitizing them depends on all values of the enum.
+var sanitizedSymbolsCache sync.Map
+
+// enumSymbols returns the Avro symbols of the values of the enum, by value index.
+func (o SchemaOptions) enumSymbols(enum protoreflect.EnumDescriptor) []string {
+	if o.SanitizeNames {
+		// the symbols are copied, since they are shared with the cache
+		return append([]string(nil), sanitizeSymbols(enum).symbols...)
+	}
+	symbols := make([]string, 0, enum.Values().Len())
+	for i := 0; i < enum.Values().Len(); i++ {
+		symbols = append(symbols, string(enum.Values().Get(i).Name()))
+	}
+	return symbols
+}
+
+// sanitizeSymbols returns the sanitized symbols of the enum, computed once per enum.
+func sanitizeSymbols(enum protoreflect.EnumDescriptor) *sanitizedSymbols {
+	if cached, ok := sanitizedSymbolsCache.Load(enum); ok {
+		return cached.(*sanitizedSymbols)
+	}
+	names := make([]string, 0, enum.Values().Len())
+	for i := 0; i < enum.Values().Len(); i++ {
+		names = append(names, string(enum.Values().Get(i).Name()))
+	}
+	result := &sanitizedSymbols{
+		symbols: sanitizeNames(names),
+		values:  make(map[string]protoreflect.EnumValueDescriptor, len(names)),
+	}
+	for i, symbol := range result.symbols {
+		result.values[symbol] = enum.Values().Get(i)
+	}
+	sanitizedSymbolsCache.Store(enum, result)
+	return result
+}
+
+// enumSymbol returns the Avro symbol of the enum value.
+func (o SchemaOptions) enumSymbol(value protoreflect.EnumValueDescriptor) string {
+	if o.SanitizeNames {
+		return sanitizeSymbols(value.Parent().(protoreflect.EnumDescriptor)).symbols[value.Index()]
+	}
+	return string(value.Name())
+}
+
+// enumValueBySymbol returns the value of the enum with the Avro symbol, or nil if there is none.
+func (o SchemaOptions) enumValueBySymbol(enum protoreflect.EnumDescriptor, symbol string) protoreflect.EnumValueDescriptor {
+	if !o.SanitizeNames {
+		return enum.Values().ByName(protoreflect.Name(symbol))
+	}
+	return sanitizeSymbols(enum).values[symbol]
+}
+
+// checkNames returns an error when the names of a named type are not valid Avro names,
+// or when the names of its fields or symbols are not unique.
+func checkNames(namespace, name string, names []string) error {
+	if err := checkNamespace(namespace); err != nil {
+		return err
+	}
+	if err := checkName(name); err != nil {
+		return err
+	}
+	seen := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		if err := checkName(name); err != nil {
+			return err
+		}
+		if _, ok := seen[name]; ok {
+			return fmt.Errorf("duplicate Avro name '%s'", name)
+		}
+		seen[name] = struct{}{}
+	}
+	return nil
+}
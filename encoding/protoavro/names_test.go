@@ -0,0 +1,108 @@
+package protoavro
+
+import (
+	"testing"
+
+	examplev1 "go.einride.tech/protobuf-avro/internal/examples/proto/gen/einride/avro/example/v1"
+	"google.golang.org/genproto/googleapis/example/library/v1"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"gotest.tools/v3/assert"
+)
+
+func Test_SanitizeNames(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		names    []string
+		expected []string
+	}{
+		{name: "valid", names: []string{"name", "_title", "Read2"}, expected: []string{"name", "_title", "Read2"}},
+		{name: "leading digit", names: []string{"2fa"}, expected: []string{"_2fa"}},
+		{name: "invalid characters", names: []string{"first-name", "ö"}, expected: []string{"first_name", "_"}},
+		{name: "empty", names: []string{""}, expected: []string{"_"}},
+		{
+			name:     "collisions",
+			names:    []string{"first-name", "first_name", "first.name"},
+			expected: []string{"first_name", "first_name_2", "first_name_3"},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got := sanitizeNames(tt.names)
+			assert.DeepEqual(t, tt.expected, got)
+			assert.NilError(t, checkNames("", "Record", got))
+		})
+	}
+}
+
+func Test_CheckNames(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		namespace   string
+		record      string
+		names       []string
+		errContains string
+	}{
+		{name: "valid", namespace: "google.example.v1", record: "Book", names: []string{"name", "title"}},
+		{name: "no namespace", record: "Book"},
+		{
+			name:        "invalid namespace",
+			namespace:   "google.1example",
+			record:      "Book",
+			errContains: "namespace 'google.1example': invalid Avro name '1example'",
+		},
+		{
+			name:        "invalid name",
+			record:      "Bo-ok",
+			errContains: "invalid Avro name 'Bo-ok'",
+		},
+		{
+			name:        "invalid field",
+			record:      "Book",
+			names:       []string{"title", "sub title"},
+			errContains: "invalid Avro name 'sub title'",
+		},
+		{
+			name:        "duplicate field",
+			record:      "Book",
+			names:       []string{"title", "title"},
+			errContains: "duplicate Avro name 'title'",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkNames(tt.namespace, tt.record, tt.names)
+			if tt.errContains == "" {
+				assert.NilError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.errContains)
+		})
+	}
+}
+
+func Test_InvalidAlias(t *testing.T) {
+	opts := SchemaOptions{
+		AliasesCallback: Aliases(map[protoreflect.FullName][]string{
+			"google.example.library.v1.Book.title": {"book-title"},
+		}),
+	}
+	_, err := opts.InferSchema((&library.Book{}).ProtoReflect().Descriptor())
+	assert.ErrorContains(t, err, "field 'google.example.library.v1.Book.title': alias: invalid Avro name 'book-title'")
+}
+
+func Test_RecordNames_Extensions(t *testing.T) {
+	types := new(protoregistry.Types)
+	assert.NilError(t, types.RegisterExtension(examplev1.E_ExtensionString))
+	opts := SchemaOptions{SanitizeNames: true, Extensions: types}
+	desc := (&examplev1.ExampleProto2{}).ProtoReflect().Descriptor()
+	names := opts.fieldNames(desc)
+	assert.Equal(t, "einride_avro_example_v1_extension_string", names[len(names)-1])
+	// the names are computed again when more extensions are registered
+	assert.NilError(t, types.RegisterExtension(examplev1.E_ExtensionInt32S))
+	names = opts.fieldNames(desc)
+	assert.Equal(t, "einride_avro_example_v1_extension_int32s", names[len(names)-1])
+	fd, ok := opts.findField(desc, "einride_avro_example_v1_extension_int32s")
+	assert.Assert(t, ok)
+	assert.Equal(t, examplev1.E_ExtensionInt32S.TypeDescriptor(), fd)
+}
@@ -1,14 +1,204 @@
 package protoavro
 
-import "google.golang.org/protobuf/reflect/protoreflect"
+import (
+	"encoding/json"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
 
 type GetDocCallback func(protoreflect.Descriptor) string
 
+// GetDefaultCallback returns the default value of a field in the inferred schema,
+// in the JSON form of the Avro field type, or false to use the inferred default value.
+type GetDefaultCallback func(protoreflect.FieldDescriptor) (interface{}, bool)
+
+// GetAliasesCallback returns the previous names of a field or message, used as Avro aliases.
+type GetAliasesCallback func(protoreflect.Descriptor) []string
+
+// Aliases returns a GetAliasesCallback that returns the aliases of fields and messages by full name.
+func Aliases(aliases map[protoreflect.FullName][]string) GetAliasesCallback {
+	return func(desc protoreflect.Descriptor) []string {
+		return aliases[desc.FullName()]
+	}
+}
+
+// GetPropsCallback returns extra attributes of the Avro schema of a field, message or enum,
+// for example metadata from their options. The names must not be names of Avro attributes.
+type GetPropsCallback func(protoreflect.Descriptor) map[string]interface{}
+
+// OptionProps returns a GetPropsCallback that copies the values of the extensions set on the options of
+// fields, messages and enums to properties named "x-" followed by the full name of the extension.
+// Enum values are copied as their names and messages as their protobuf JSON encoding.
+func OptionProps(extensions ...protoreflect.ExtensionType) GetPropsCallback {
+	return func(desc protoreflect.Descriptor) map[string]interface{} {
+		options := desc.Options()
+		var props map[string]interface{}
+		for _, extension := range extensions {
+			if !proto.HasExtension(options, extension) {
+				continue
+			}
+			field := extension.TypeDescriptor()
+			value, ok := optionValue(field, options.ProtoReflect().Get(field))
+			if !ok {
+				continue
+			}
+			if props == nil {
+				props = make(map[string]interface{})
+			}
+			props["x-"+string(field.FullName())] = value
+		}
+		return props
+	}
+}
+
+// optionValue returns the value of an option field in the form of a JSON value.
+func optionValue(field protoreflect.FieldDescriptor, value protoreflect.Value) (interface{}, bool) {
+	if field.IsList() {
+		list := value.List()
+		values := make([]interface{}, 0, list.Len())
+		for i := 0; i < list.Len(); i++ {
+			v, ok := optionScalar(field, list.Get(i))
+			if !ok {
+				return nil, false
+			}
+			values = append(values, v)
+		}
+		return values, true
+	}
+	return optionScalar(field, value)
+}
+
+func optionScalar(field protoreflect.FieldDescriptor, value protoreflect.Value) (interface{}, bool) {
+	switch field.Kind() {
+	case protoreflect.EnumKind:
+		if v := field.Enum().Values().ByNumber(value.Enum()); v != nil {
+			return string(v.Name()), true
+		}
+		return int32(value.Enum()), true
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		b, err := protojson.Marshal(value.Message().Interface())
+		if err != nil {
+			return nil, false
+		}
+		return json.RawMessage(b), true
+	}
+	return value.Interface(), true
+}
+
+// IsUUIDCallback reports whether a string field holds UUIDs.
+type IsUUIDCallback func(protoreflect.FieldDescriptor) bool
+
+// UUIDFields returns an IsUUIDCallback that selects the string fields with the given full names.
+func UUIDFields(names ...protoreflect.FullName) IsUUIDCallback {
+	selected := make(map[protoreflect.FullName]struct{}, len(names))
+	for _, name := range names {
+		selected[name] = struct{}{}
+	}
+	return func(field protoreflect.FieldDescriptor) bool {
+		_, ok := selected[field.FullName()]
+		return ok
+	}
+}
+
+// FieldTransformer is called with the value of each field when encoding or decoding a message.
+// It returns the value to use in its place, or false to drop the field.
+// Dropped fields are encoded as null, or as the zero value of their type when it is not nullable,
+// such as for required fields, and left unset when decoding.
+type FieldTransformer func(field protoreflect.FieldDescriptor, value protoreflect.Value) (protoreflect.Value, bool, error)
+
 // SchemaOptions contains configuration options for Avro schema inference.
 // OmitRootElement is used to determine whether the root element of a message should be omitted, when writing to Avro.
 // DocCallback is used to determine the documentation for a field or message.
+// AliasesCallback is used to determine the aliases of fields and records, for example after
+// fields or messages have been renamed. Aliases are also matched when decoding, after the field numbers
+// in the proto.number properties of fields, which are always added.
+// DefaultCallback is used to override the default values of fields, which are otherwise null
+// for nullable fields and the zero value of the Avro type for other fields.
+// FieldMask is used to select the field paths, relative to the root message, that are included in
+// the schema and encoded records, and that are decoded when reading.
+// EncodeTransformer and DecodeTransformer are used to transform field values, for example to redact them,
+// when encoding and decoding messages.
+// FixedSizes is used to map bytes fields, by full name, to Avro fixed types of the given size.
+// UUIDCallback is used to determine which string fields are mapped to the Avro uuid logical type.
+// SanitizeNames is used to replace characters that are not allowed in Avro names of records, fields,
+// enums and enum symbols with underscores, and to make the names unique. Without it, schema inference
+// fails for names that are not valid Avro names. Decoding maps sanitized names back to the protobuf names.
+// EnumEncoding is used to determine whether enums are encoded as Avro enums, numbers or records.
+// UnknownEnum is used to determine how enum values unknown to the enum descriptor are handled.
+// StructuredWKT is used to encode google.protobuf.Struct, Value and Any as Avro maps and records
+// instead of JSON strings.
+// AnyTypes is used, together with StructuredWKT, to encode google.protobuf.Any as a union of
+// the message types in the registry instead of as a record with the type URL and serialized value.
+// AnyResolver is used to resolve the types of google.protobuf.Any values encoded as JSON strings,
+// and defaults to protoregistry.GlobalTypes.
+// UnknownAny is used to determine how google.protobuf.Any values with unresolvable types are handled.
+// PropsCallback is used to determine extra attributes of the schemas of fields, records and enums,
+// for example from options with OptionProps.
+// ProtoMetadata is used to add the property proto.type to fields, and proto.full_name
+// and proto.file to records and enums, to trace schemas back to their protobuf definitions.
+// Profile is used to adjust schema inference and encoding to a consumer of the Avro files, such as BigQuery.
+// DropDateTimeOffsets is used, with ProfileBigQuery, to encode google.type.DateTime values with a time zone
+// or UTC offset without it, instead of failing.
+// Extensions is used to include the extensions of messages that are registered in the registry as
+// fields, named by the full names of the extensions with dots replaced by underscores.
+// FieldIDs is used to annotate fields, arrays and maps with Apache Iceberg field IDs derived from the
+// protobuf field numbers. Unmarshalers match fields by the IDs in the schema of the file, before
+// matching them by number and name.
+// PreserveUnknownFields is used to add a nullable _unknown bytes field to the records of messages,
+// with the unknown fields of the messages in protobuf wire format, which are restored when decoding.
+// It is not added when a FieldMask is used.
+// RawMessage is used to add a field to the records of the root message with the messages in protobuf
+// wire format, which Unmarshalers can decode instead of the other fields for exact round trips.
+// It is not added when a FieldMask is used.
 type SchemaOptions struct {
-	OmitRootElement bool
-	DocCallback     GetDocCallback
-	OmitNullArray   bool // don't nullify arrays and their elements
+	OmitRootElement       bool
+	DocCallback           GetDocCallback
+	AliasesCallback       GetAliasesCallback
+	DefaultCallback       GetDefaultCallback
+	OmitNullArray         bool // don't nullify arrays and their elements
+	FieldMask             *fieldmaskpb.FieldMask
+	EncodeTransformer     FieldTransformer
+	DecodeTransformer     FieldTransformer
+	FixedSizes            map[protoreflect.FullName]int
+	UUIDCallback          IsUUIDCallback
+	SanitizeNames         bool
+	EnumEncoding          EnumEncoding
+	UnknownEnum           UnknownEnumPolicy
+	StructuredWKT         bool
+	AnyTypes              *protoregistry.Types
+	AnyResolver           protoregistry.MessageTypeResolver
+	UnknownAny            UnknownAnyPolicy
+	PropsCallback         GetPropsCallback
+	ProtoMetadata         bool
+	Profile               Profile
+	DropDateTimeOffsets   bool
+	Extensions            *protoregistry.Types
+	FieldIDs              bool
+	PreserveUnknownFields bool
+	RawMessage            *RawMessage
+
+	// writerFields maps the full names of records in the schema of the file being decoded
+	// to the field IDs and numbers of their fields by name.
+	writerFields map[string]map[string]writerField
+	// fieldAliases maps the full names of messages being decoded to their fields by alias.
+	fieldAliases map[protoreflect.FullName]map[string]protoreflect.FieldDescriptor
+	// rootMessage is the full name of the message being inferred, encoded or decoded.
+	rootMessage protoreflect.FullName
+}
+
+// fixedSize returns the size of the fixed type of a bytes field, or false when it is not mapped to fixed.
+func (o SchemaOptions) fixedSize(field protoreflect.FieldDescriptor) (int, bool) {
+	if field.Kind() != protoreflect.BytesKind {
+		return 0, false
+	}
+	size, ok := o.FixedSizes[field.FullName()]
+	return size, ok
+}
+
+func (o SchemaOptions) isUUID(field protoreflect.FieldDescriptor) bool {
+	return o.UUIDCallback != nil && field.Kind() == protoreflect.StringKind && o.UUIDCallback(field)
 }
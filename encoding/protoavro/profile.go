@@ -0,0 +1,119 @@
+package protoavro
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"go.einride.tech/protobuf-avro/avro"
+	"go.einride.tech/protobuf-avro/internal/wkt"
+	"google.golang.org/genproto/googleapis/type/datetime"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Profile adjusts schema inference and encoding to the requirements of a consumer of the Avro files.
+type Profile int
+
+const (
+	// ProfileDefault infers schemas and encodes messages according to the other options only.
+	ProfileDefault Profile = iota
+	// ProfileBigQuery infers schemas of shapes that BigQuery load jobs accept:
+	//   - arrays and their elements are not nullable, as with OmitNullArray,
+	//   - unions of several message types, used for google.protobuf.Any with AnyTypes, are flattened
+	//     into records with one nullable field per type,
+	//   - google.protobuf.Struct and Value are encoded as JSON strings, also with StructuredWKT,
+	//     since their structured encoding is recursive and nests arrays,
+	//   - google.type.DateTime is encoded as a string with the datetime logical type, which
+	//     BigQuery loads as DATETIME. Values with a time zone or UTC offset fail to encode,
+	//     unless DropDateTimeOffsets is set, and values out of the DATETIME range or with
+	//     sub-microsecond nanos, which DATETIME can not represent, fail to encode.
+	//
+	// Nested repeated fields need no wrapping: protobuf has no repeated fields of repeated fields,
+	// so the elements of arrays are records, and ListValue, the only other source of nested arrays,
+	// is encoded as a JSON string.
+	ProfileBigQuery
+)
+
+// omitNullArray reports whether arrays and their elements are not nullable.
+func (o SchemaOptions) omitNullArray() bool {
+	return o.OmitNullArray || o.Profile == ProfileBigQuery
+}
+
+// structuredStruct reports whether google.protobuf.Struct and Value are encoded as maps and records.
+func (o SchemaOptions) structuredStruct() bool {
+	return o.StructuredWKT && o.Profile != ProfileBigQuery
+}
+
+// flattenUnions reports whether unions of several message types are flattened into records.
+func (o SchemaOptions) flattenUnions() bool {
+	return o.Profile == ProfileBigQuery
+}
+
+// isWKT reports whether the message is encoded with a special mapping,
+// including the mappings of the profile.
+func (o SchemaOptions) isWKT(name protoreflect.FullName) bool {
+	return isWKT(name) || (o.Profile == ProfileBigQuery && name == wkt.DateTime)
+}
+
+// flattenedFieldName returns the name of the field of a message type in a flattened union.
+func flattenedFieldName(fullName string) string {
+	return strings.ReplaceAll(fullName, ".", "_")
+}
+
+// dateTimeLayout is the format of BigQuery DATETIME values, which have microsecond precision.
+const dateTimeLayout = "2006-01-02T15:04:05.999999"
+
+func schemaDateTime() avro.Schema {
+	return avro.Nullable(avro.DateTime())
+}
+
+func (o SchemaOptions) encodeDateTime(d *datetime.DateTime) (map[string]interface{}, error) {
+	if !o.DropDateTimeOffsets {
+		if d.GetTimeZone() != nil {
+			return nil, fmt.Errorf("google.type.DateTime: time zone %s can not be encoded as DATETIME", d.GetTimeZone().GetId())
+		}
+		if d.GetUtcOffset() != nil {
+			return nil, fmt.Errorf(
+				"google.type.DateTime: UTC offset %s can not be encoded as DATETIME", d.GetUtcOffset().AsDuration(),
+			)
+		}
+	}
+	c := civil.DateTime{
+		Date: civil.Date{Year: int(d.GetYear()), Month: time.Month(d.GetMonth()), Day: int(d.GetDay())},
+		Time: civil.Time{
+			Hour:       int(d.GetHours()),
+			Minute:     int(d.GetMinutes()),
+			Second:     int(d.GetSeconds()),
+			Nanosecond: int(d.GetNanos()),
+		},
+	}
+	if d.GetNanos()%int32(time.Microsecond) != 0 {
+		return nil, fmt.Errorf("google.type.DateTime: nanos %d can not be encoded as DATETIME", d.GetNanos())
+	}
+	// year 0, which DateTime uses for values without a year, is out of the DATETIME range
+	if !c.IsValid() || c.Date.Year < 1 || c.Date.Year > 9999 {
+		return nil, fmt.Errorf("google.type.DateTime: invalid value %s", c)
+	}
+	return o.unionValue("string", c.In(time.UTC).Format(dateTimeLayout)), nil
+}
+
+func decodeDateTime(v map[string]interface{}) (*datetime.DateTime, error) {
+	str, err := decodeString(v, "string")
+	if err != nil {
+		return nil, fmt.Errorf("google.type.DateTime: %w", err)
+	}
+	d, err := civil.ParseDateTime(str)
+	if err != nil {
+		return nil, fmt.Errorf("google.type.DateTime: %w", err)
+	}
+	return &datetime.DateTime{
+		Year:    int32(d.Date.Year),
+		Month:   int32(d.Date.Month),
+		Day:     int32(d.Date.Day),
+		Hours:   int32(d.Time.Hour),
+		Minutes: int32(d.Time.Minute),
+		Seconds: int32(d.Time.Second),
+		Nanos:   int32(d.Time.Nanosecond),
+	}, nil
+}
@@ -0,0 +1,205 @@
+package protoavro
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/linkedin/goavro/v2"
+	"go.einride.tech/protobuf-avro/avro"
+	examplev1 "go.einride.tech/protobuf-avro/internal/examples/proto/gen/einride/avro/example/v1"
+	"google.golang.org/genproto/googleapis/example/library/v1"
+	"google.golang.org/genproto/googleapis/type/date"
+	"google.golang.org/genproto/googleapis/type/datetime"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gotest.tools/v3/assert"
+)
+
+func Test_ProfileBigQuery(t *testing.T) {
+	types := new(protoregistry.Types)
+	assert.NilError(t, types.RegisterMessage((&library.Book{}).ProtoReflect().Type()))
+	assert.NilError(t, types.RegisterMessage((&examplev1.ExampleEnum{}).ProtoReflect().Type()))
+	msg := &examplev1.ExampleRepeatedWKT{
+		Timestamps: []*timestamppb.Timestamp{timestamppb.New(time.Unix(1, 2000)), timestamppb.New(time.Unix(3, 0))},
+		Durations:  []*durationpb.Duration{durationpb.New(time.Second)},
+		Dates:      []*date.Date{{Year: 2020, Month: 1, Day: 2}},
+		DateTimes: []*datetime.DateTime{
+			{Year: 2020, Month: 1, Day: 2, Hours: 3, Minutes: 4, Seconds: 5, Nanos: 6000},
+			{Year: 2021, Month: 12, Day: 31},
+		},
+		Structs: []*structpb.Struct{{
+			Fields: map[string]*structpb.Value{"list": structpb.NewListValue(&structpb.ListValue{
+				Values: []*structpb.Value{structpb.NewListValue(&structpb.ListValue{})},
+			})},
+		}},
+		Anys: []*anypb.Any{
+			mustAny(t, &library.Book{Name: "shelves/1/books/1"}),
+			mustAny(t, &examplev1.ExampleEnum{EnumValue: examplev1.ExampleEnum_ENUM_VALUE1}),
+		},
+	}
+	for _, tt := range []struct {
+		name string
+		opts SchemaOptions
+	}{
+		{
+			name: "profile",
+			opts: SchemaOptions{Profile: ProfileBigQuery},
+		},
+		{
+			name: "profile with structured types",
+			opts: SchemaOptions{Profile: ProfileBigQuery, StructuredWKT: true, AnyTypes: types},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			schema, err := tt.opts.InferSchema(msg.ProtoReflect().Descriptor())
+			assert.NilError(t, err)
+			assert.NilError(t, avro.Validate(schema))
+			schemaBytes, err := json.Marshal(schema)
+			assert.NilError(t, err)
+			codec, err := goavro.NewCodec(string(schemaBytes))
+			assert.NilError(t, err)
+
+			encoded, err := tt.opts.encodeJSON(msg)
+			assert.NilError(t, err)
+			binary, err := codec.BinaryFromNative(nil, encoded)
+			assert.NilError(t, err)
+			native, _, err := codec.NativeFromBinary(binary)
+			assert.NilError(t, err)
+
+			decoded := &examplev1.ExampleRepeatedWKT{}
+			assert.NilError(t, tt.opts.decodeJSON(native, decoded))
+			assert.DeepEqual(t, msg, decoded, protocmp.Transform())
+		})
+	}
+
+	t.Run("schema", func(t *testing.T) {
+		opts := SchemaOptions{OmitRootElement: true, Profile: ProfileBigQuery, StructuredWKT: true, AnyTypes: types}
+		schema, err := opts.InferSchema(msg.ProtoReflect().Descriptor())
+		assert.NilError(t, err)
+		fields := schema.(avro.Record).Fields
+		assert.Equal(t, 6, len(fields))
+		assert.DeepEqual(t, avro.Array{Type: avro.ArrayType, Items: avro.TimestampMicros()}, fields[0].Type)
+		assert.DeepEqual(t, avro.Array{Type: avro.ArrayType, Items: avro.DateTime()}, fields[3].Type)
+		assert.DeepEqual(t, avro.Array{Type: avro.ArrayType, Items: avro.String()}, fields[4].Type)
+		anys := fields[5].Type.(avro.Array).Items.(avro.Record)
+		assert.Equal(t, "AnyTypes", anys.Name)
+		assert.Equal(t, "google.protobuf", anys.Namespace)
+		assert.Equal(t, "einride_avro_example_v1_ExampleEnum", anys.Fields[0].Name)
+		assert.Equal(t, "google_example_library_v1_Book", anys.Fields[1].Name)
+	})
+
+	t.Run("datetime precision", func(t *testing.T) {
+		opts := SchemaOptions{Profile: ProfileBigQuery}
+		encoded, err := opts.encodeDateTime(&datetime.DateTime{
+			Year: 2020, Month: 1, Day: 2, Hours: 3, Minutes: 4, Seconds: 5, Nanos: 123456000,
+		})
+		assert.NilError(t, err)
+		assert.DeepEqual(t, map[string]interface{}{"string": "2020-01-02T03:04:05.123456"}, encoded)
+		_, err = opts.encodeDateTime(&datetime.DateTime{
+			Year: 2020, Month: 1, Day: 2, Hours: 3, Minutes: 4, Seconds: 5, Nanos: 123456789,
+		})
+		assert.Error(t, err, "google.type.DateTime: nanos 123456789 can not be encoded as DATETIME")
+	})
+
+	t.Run("datetime offsets", func(t *testing.T) {
+		withZone := &datetime.DateTime{
+			Year: 2020, Month: 1, Day: 2,
+			TimeOffset: &datetime.DateTime_TimeZone{TimeZone: &datetime.TimeZone{Id: "Europe/Stockholm"}},
+		}
+		withOffset := &datetime.DateTime{
+			Year: 2020, Month: 1, Day: 2,
+			TimeOffset: &datetime.DateTime_UtcOffset{UtcOffset: durationpb.New(time.Hour)},
+		}
+		opts := SchemaOptions{Profile: ProfileBigQuery}
+		_, err := opts.encodeDateTime(withZone)
+		assert.Error(t, err, "google.type.DateTime: time zone Europe/Stockholm can not be encoded as DATETIME")
+		_, err = opts.encodeDateTime(withOffset)
+		assert.Error(t, err, "google.type.DateTime: UTC offset 1h0m0s can not be encoded as DATETIME")
+		opts.DropDateTimeOffsets = true
+		for _, d := range []*datetime.DateTime{withZone, withOffset} {
+			encoded, err := opts.encodeDateTime(d)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, map[string]interface{}{"string": "2020-01-02T00:00:00"}, encoded)
+		}
+	})
+
+	t.Run("datetime ranges", func(t *testing.T) {
+		opts := SchemaOptions{Profile: ProfileBigQuery}
+		for _, tt := range []struct {
+			name        string
+			value       *datetime.DateTime
+			expectedErr string
+		}{
+			{
+				name:        "month",
+				value:       &datetime.DateTime{Year: 2020, Month: 13, Day: 1},
+				expectedErr: "google.type.DateTime: invalid value 2020-13-01T00:00:00",
+			},
+			{
+				name:        "day",
+				value:       &datetime.DateTime{Year: 2021, Month: 2, Day: 29},
+				expectedErr: "google.type.DateTime: invalid value 2021-02-29T00:00:00",
+			},
+			{
+				name:        "hours",
+				value:       &datetime.DateTime{Year: 2020, Month: 1, Day: 2, Hours: 24},
+				expectedErr: "google.type.DateTime: invalid value 2020-01-02T24:00:00",
+			},
+			{
+				name:        "seconds",
+				value:       &datetime.DateTime{Year: 2020, Month: 1, Day: 2, Seconds: 60},
+				expectedErr: "google.type.DateTime: invalid value 2020-01-02T00:00:60",
+			},
+			{
+				name:        "no year",
+				value:       &datetime.DateTime{Month: 1, Day: 2},
+				expectedErr: "google.type.DateTime: invalid value 0000-01-02T00:00:00",
+			},
+		} {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				_, err := opts.encodeDateTime(tt.value)
+				assert.Error(t, err, tt.expectedErr)
+			})
+		}
+	})
+}
+
+func Test_OmitNullArrayWKT(t *testing.T) {
+	msg := &examplev1.ExampleRepeatedWKT{
+		Timestamps: []*timestamppb.Timestamp{timestamppb.New(time.Unix(1, 2000))},
+		Durations:  []*durationpb.Duration{durationpb.New(time.Second)},
+		Dates:      []*date.Date{{Year: 2020, Month: 1, Day: 2}},
+		DateTimes:  []*datetime.DateTime{{Year: 2020, Month: 1, Day: 2}},
+		Structs: []*structpb.Struct{{
+			Fields: map[string]*structpb.Value{"number": structpb.NewNumberValue(1)},
+		}},
+		Anys: []*anypb.Any{mustAny(t, &library.Book{Name: "shelves/1/books/1"})},
+	}
+	for _, opts := range []SchemaOptions{
+		{OmitNullArray: true},
+		{OmitNullArray: true, StructuredWKT: true},
+	} {
+		schema, err := opts.InferSchema(msg.ProtoReflect().Descriptor())
+		assert.NilError(t, err)
+		schemaBytes, err := json.Marshal(schema)
+		assert.NilError(t, err)
+		codec, err := goavro.NewCodec(string(schemaBytes))
+		assert.NilError(t, err)
+		encoded, err := opts.encodeJSON(msg)
+		assert.NilError(t, err)
+		binary, err := codec.BinaryFromNative(nil, encoded)
+		assert.NilError(t, err)
+		native, _, err := codec.NativeFromBinary(binary)
+		assert.NilError(t, err)
+		decoded := &examplev1.ExampleRepeatedWKT{}
+		assert.NilError(t, opts.decodeJSON(native, decoded))
+		assert.DeepEqual(t, msg, decoded, protocmp.Transform())
+	}
+}
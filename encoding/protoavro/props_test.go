@@ -0,0 +1,75 @@
+package protoavro
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/linkedin/goavro/v2"
+	"go.einride.tech/protobuf-avro/avro"
+	examplev1 "go.einride.tech/protobuf-avro/internal/examples/proto/gen/einride/avro/example/v1"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/genproto/googleapis/example/library/v1"
+	"gotest.tools/v3/assert"
+)
+
+func Test_OptionProps(t *testing.T) {
+	opts := SchemaOptions{
+		OmitRootElement: true,
+		PropsCallback:   OptionProps(annotations.E_FieldBehavior, annotations.E_Resource),
+	}
+	schema, err := opts.InferSchema((&library.CreateBookRequest{}).ProtoReflect().Descriptor())
+	assert.NilError(t, err)
+	record := schema.(avro.Record)
+	assert.Equal(t, "parent", record.Fields[0].Name)
+	assert.DeepEqual(t, map[string]interface{}{
+		"proto.number":                1,
+		"x-google.api.field_behavior": []interface{}{"REQUIRED"},
+	}, record.Fields[0].Props)
+
+	book := record.Fields[1].Type.(avro.Union)[1].(avro.Record)
+	assert.Equal(t, "Book", book.Name)
+	assert.DeepEqual(t, numberProps(1), book.Fields[0].Props)
+	resource, err := json.Marshal(book.Props["x-google.api.resource"])
+	assert.NilError(t, err)
+	assert.Equal(
+		t,
+		`{"type":"library-example.googleapis.com/Book","pattern":["shelves/{shelf}/books/{book}"]}`,
+		string(resource),
+	)
+
+	// properties are ignored by Avro readers
+	schemaBytes, err := json.Marshal(schema)
+	assert.NilError(t, err)
+	_, err = goavro.NewCodec(string(schemaBytes))
+	assert.NilError(t, err)
+}
+
+func Test_ProtoMetadata(t *testing.T) {
+	opts := SchemaOptions{
+		OmitRootElement: true,
+		ProtoMetadata:   true,
+		PropsCallback:   OptionProps(annotations.E_FieldBehavior),
+	}
+	schema, err := opts.InferSchema((&examplev1.ExampleMap{}).ProtoReflect().Descriptor())
+	assert.NilError(t, err)
+	record := schema.(avro.Record)
+	assert.DeepEqual(t, map[string]interface{}{
+		"proto.full_name": "einride.avro.example.v1.ExampleMap",
+		"proto.file":      "einride/avro/example/v1/example_map.proto",
+	}, record.Props)
+	assert.DeepEqual(t, map[string]interface{}{
+		"proto.number": 2,
+		"proto.type":   "map<string, einride.avro.example.v1.ExampleMap.Nested>",
+	}, record.Fields[1].Props)
+	entry := record.Fields[0].Type.(avro.Union)[1].(avro.Array).Items.(avro.Record)
+	assert.DeepEqual(t, map[string]interface{}{"proto.number": 1, "proto.type": "string"}, entry.Fields[0].Props)
+
+	// metadata is merged with the properties of the callback
+	schema, err = opts.InferSchema((&library.CreateBookRequest{}).ProtoReflect().Descriptor())
+	assert.NilError(t, err)
+	assert.DeepEqual(t, map[string]interface{}{
+		"proto.number":                1,
+		"proto.type":                  "string",
+		"x-google.api.field_behavior": []interface{}{"REQUIRED"},
+	}, schema.(avro.Record).Fields[0].Props)
+}
@@ -0,0 +1,89 @@
+package protoavro
+
+import (
+	"math"
+	"sort"
+
+	"go.einride.tech/protobuf-avro/avro"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// isRequired reports whether the field is a proto2 required field,
+// which is mapped to a type that is not nullable.
+func isRequired(field protoreflect.FieldDescriptor) bool {
+	return field.Cardinality() == protoreflect.Required
+}
+
+// nonNullable returns the type of a nullable union that is not null.
+func nonNullable(schema avro.Schema) avro.Schema {
+	u, ok := schema.(avro.Union)
+	if !ok || len(u) != 2 {
+		return schema
+	}
+	for i, branch := range u {
+		if p, ok := branch.(avro.Primitive); ok && p.Type == avro.NullType {
+			return u[1-i]
+		}
+	}
+	return schema
+}
+
+// recordFields returns the fields of the record of the message: the fields of the message,
+// followed by the extensions of the message in Extensions ordered by number.
+func (o SchemaOptions) recordFields(desc protoreflect.MessageDescriptor) []protoreflect.FieldDescriptor {
+	fields := make([]protoreflect.FieldDescriptor, 0, desc.Fields().Len())
+	for i := 0; i < desc.Fields().Len(); i++ {
+		fields = append(fields, desc.Fields().Get(i))
+	}
+	if o.Extensions == nil || desc.ExtensionRanges().Len() == 0 {
+		return fields
+	}
+	var extensions []protoreflect.FieldDescriptor
+	o.Extensions.RangeExtensionsByMessage(desc.FullName(), func(xt protoreflect.ExtensionType) bool {
+		extensions = append(extensions, xt.TypeDescriptor())
+		return true
+	})
+	sort.Slice(extensions, func(i, j int) bool {
+		return extensions[i].Number() < extensions[j].Number()
+	})
+	return append(fields, extensions...)
+}
+
+// declaredDefault returns the default value declared for a proto2 field, in the JSON form
+// of the Avro type of the field, or false when the field has no declared default.
+func (o SchemaOptions) declaredDefault(field protoreflect.FieldDescriptor) (interface{}, bool) {
+	if !field.HasDefault() {
+		return nil, false
+	}
+	value := field.Default()
+	switch field.Kind() {
+	case protoreflect.EnumKind:
+		enumValue := field.DefaultEnumValue()
+		switch o.EnumEncoding {
+		case EnumNumber:
+			return int32(enumValue.Number()), true
+		case EnumRecord:
+			// defaults of unions are values of the first branch, which is null for the name
+			return map[string]interface{}{"number": int32(enumValue.Number()), "name": nil}, true
+		}
+		return o.enumSymbol(enumValue), true
+	case protoreflect.BytesKind:
+		// bytes defaults are strings of the code points of the bytes
+		runes := make([]rune, 0, len(value.Bytes()))
+		for _, b := range value.Bytes() {
+			runes = append(runes, rune(b))
+		}
+		return string(runes), true
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		if math.IsNaN(value.Float()) || math.IsInf(value.Float(), 0) {
+			// not representable in JSON
+			return nil, false
+		}
+		return value.Float(), true
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return int32(value.Uint()), true
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return int64(value.Uint()), true
+	}
+	return value.Interface(), true
+}
@@ -0,0 +1,188 @@
+package protoavro
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/linkedin/goavro/v2"
+	"go.einride.tech/protobuf-avro/avro"
+	examplev1 "go.einride.tech/protobuf-avro/internal/examples/proto/gen/einride/avro/example/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gotest.tools/v3/assert"
+)
+
+func Test_Proto2(t *testing.T) {
+	types := new(protoregistry.Types)
+	assert.NilError(t, types.RegisterExtension(examplev1.E_ExtensionString))
+	assert.NilError(t, types.RegisterExtension(examplev1.E_ExtensionInt32S))
+	assert.NilError(t, types.RegisterExtension(examplev1.E_ExtensionNested))
+	opts := SchemaOptions{OmitRootElement: true, Extensions: types}
+	desc := (&examplev1.ExampleProto2{}).ProtoReflect().Descriptor()
+
+	t.Run("schema", func(t *testing.T) {
+		schema, err := opts.InferSchema(desc)
+		assert.NilError(t, err)
+		assert.NilError(t, avro.Validate(schema))
+		fields := make(map[string]avro.Field)
+		for _, field := range schema.(avro.Record).Fields {
+			fields[field.Name] = field
+		}
+		// required fields are not nullable, and have no default
+		assert.DeepEqual(t, avro.Field{
+			Name:  "required_string",
+			Type:  avro.String(),
+			Props: numberProps(1),
+		}, fields["required_string"])
+		assert.Equal(t, "Nested", fields["required_message"].Type.(avro.Record).Name)
+		// declared defaults are defaults of the first branch
+		assert.DeepEqual(t, avro.Field{
+			Name:    "default_int32",
+			Type:    avro.Union{avro.Integer(), avro.Null()},
+			Default: int32(42),
+			Props:   numberProps(3),
+		}, fields["default_int32"])
+		assert.Equal(t, "ENUM_VALUE2", fields["default_enum"].Default)
+		assert.Equal(t, "\u0000ÿ", fields["default_bytes"].Default)
+		assert.DeepEqual(t, avro.NullDefault, fields["optional_string"].Default)
+		// groups are mapped as records named by the group
+		assert.Equal(t, "OptionalGroup", fields["optionalgroup"].Type.(avro.Union)[1].(avro.Record).Name)
+		// extensions are fields named by their full names
+		assert.DeepEqual(t, avro.Nullable(avro.String()), fields["einride_avro_example_v1_extension_string"].Type)
+		assert.Assert(t, fields["einride_avro_example_v1_extension_nested"].Type != nil)
+	})
+
+	t.Run("roundtrip", func(t *testing.T) {
+		for _, tt := range []struct {
+			name string
+			msg  *examplev1.ExampleProto2
+		}{
+			{
+				name: "unset optional fields",
+				msg: &examplev1.ExampleProto2{
+					RequiredString:  proto.String(""),
+					RequiredMessage: &examplev1.ExampleProto2_Nested{RequiredInt64: proto.Int64(1)},
+				},
+			},
+			{
+				name: "set fields",
+				msg: func() *examplev1.ExampleProto2 {
+					msg := &examplev1.ExampleProto2{
+						RequiredString:    proto.String("required"),
+						OptionalString:    proto.String(""),
+						DefaultInt32:      proto.Int32(0),
+						DefaultString:     proto.String("world"),
+						DefaultEnum:       examplev1.ExampleProto2_ENUM_VALUE1.Enum(),
+						DefaultBytes:      []byte{},
+						DefaultBool:       proto.Bool(false),
+						DefaultDouble:     proto.Float64(2.5),
+						RequiredMessage:   &examplev1.ExampleProto2_Nested{RequiredInt64: proto.Int64(1)},
+						Optionalgroup:     &examplev1.ExampleProto2_OptionalGroup{GroupString: proto.String("group")},
+						RepeatedInt64:     []int64{1, 2},
+						OptionalTimestamp: timestamppb.New(time.Unix(1, 0)),
+						OptionalEnum:      examplev1.ExampleProto2_ENUM_VALUE2.Enum(),
+					}
+					proto.SetExtension(msg, examplev1.E_ExtensionString, "extension")
+					proto.SetExtension(msg, examplev1.E_ExtensionInt32S, []int32{3, 4})
+					proto.SetExtension(
+						msg,
+						examplev1.E_ExtensionNested,
+						&examplev1.ExampleProto2_Nested{RequiredInt64: proto.Int64(5)},
+					)
+					return msg
+				}(),
+			},
+		} {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				schema, err := opts.InferSchema(desc)
+				assert.NilError(t, err)
+				schemaBytes, err := json.Marshal(schema)
+				assert.NilError(t, err)
+				codec, err := goavro.NewCodec(string(schemaBytes))
+				assert.NilError(t, err)
+				encoded, err := opts.encodeJSON(tt.msg)
+				assert.NilError(t, err)
+				binary, err := codec.BinaryFromNative(nil, encoded)
+				assert.NilError(t, err)
+				native, _, err := codec.NativeFromBinary(binary)
+				assert.NilError(t, err)
+				decoded := &examplev1.ExampleProto2{}
+				assert.NilError(t, opts.decodeJSON(native, decoded))
+				assert.DeepEqual(t, tt.msg, decoded, protocmp.Transform())
+			})
+		}
+	})
+
+	t.Run("unset optional fields are null", func(t *testing.T) {
+		encoded, err := opts.encodeJSON(&examplev1.ExampleProto2{
+			RequiredString:  proto.String(""),
+			RequiredMessage: &examplev1.ExampleProto2_Nested{RequiredInt64: proto.Int64(1)},
+		})
+		assert.NilError(t, err)
+		record := encoded.(map[string]interface{})
+		assert.Equal(t, "", record["required_string"])
+		assert.Assert(t, record["default_int32"] == nil)
+		assert.Assert(t, record["default_enum"] == nil)
+	})
+
+	t.Run("missing required fields", func(t *testing.T) {
+		_, err := opts.encodeJSON(&examplev1.ExampleProto2{RequiredString: proto.String("")})
+		assert.Error(t, err, "required field einride.avro.example.v1.ExampleProto2.required_message is not set")
+		err = opts.decodeJSON(map[string]interface{}{"required_string": ""}, &examplev1.ExampleProto2{})
+		assert.Error(t, err, "required field einride.avro.example.v1.ExampleProto2.required_message is not set")
+	})
+	t.Run("dropped required fields", func(t *testing.T) {
+		opts := opts
+		opts.EncodeTransformer = func(
+			field protoreflect.FieldDescriptor,
+			value protoreflect.Value,
+		) (protoreflect.Value, bool, error) {
+			return value, !isRequired(field), nil
+		}
+		schema, err := opts.InferSchema(desc)
+		assert.NilError(t, err)
+		schemaBytes, err := json.Marshal(schema)
+		assert.NilError(t, err)
+		codec, err := goavro.NewCodec(string(schemaBytes))
+		assert.NilError(t, err)
+		encoded, err := opts.encodeJSON(&examplev1.ExampleProto2{
+			RequiredString:  proto.String("required"),
+			RequiredMessage: &examplev1.ExampleProto2_Nested{RequiredInt64: proto.Int64(1)},
+		})
+		assert.NilError(t, err)
+		// dropped required fields are the zero values of their types, which are not nullable
+		record := encoded.(map[string]interface{})
+		assert.Equal(t, "", record["required_string"])
+		assert.DeepEqual(t, map[string]interface{}{"required_int64": int64(0)}, record["required_message"])
+		_, err = codec.BinaryFromNative(nil, encoded)
+		assert.NilError(t, err)
+		// dropped required uuid fields are the nil uuid
+		opts.UUIDCallback = UUIDFields("einride.avro.example.v1.ExampleProto2.required_string")
+		encoded, err = opts.encodeJSON(&examplev1.ExampleProto2{
+			RequiredString:  proto.String("required"),
+			RequiredMessage: &examplev1.ExampleProto2_Nested{RequiredInt64: proto.Int64(1)},
+		})
+		assert.NilError(t, err)
+		assert.Equal(t, nilUUID, encoded.(map[string]interface{})["required_string"])
+		// dropped required fields of the raw message are zero values
+		opts.UUIDCallback = nil
+		opts.RawMessage = &RawMessage{}
+		encoded, err = opts.encodeJSON(&examplev1.ExampleProto2{
+			RequiredString:  proto.String("required"),
+			RequiredMessage: &examplev1.ExampleProto2_Nested{RequiredInt64: proto.Int64(1)},
+		})
+		assert.NilError(t, err)
+		raw := encoded.(map[string]interface{})[defaultRawMessageName].(map[string]interface{})["bytes"].([]byte)
+		var got examplev1.ExampleProto2
+		assert.NilError(t, proto.UnmarshalOptions{AllowPartial: true}.Unmarshal(raw, &got))
+		assert.DeepEqual(t, &examplev1.ExampleProto2{
+			RequiredString:  proto.String(""),
+			RequiredMessage: &examplev1.ExampleProto2_Nested{},
+		}, &got, protocmp.Transform())
+	})
+}
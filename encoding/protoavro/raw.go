@@ -0,0 +1,178 @@
+package protoavro
+
+import (
+	"fmt"
+
+	"go.einride.tech/protobuf-avro/avro"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// RawMessage configures a field of the root record with the messages in protobuf wire format,
+// next to the fields of the messages.
+type RawMessage struct {
+	// Name is the name of the field, and defaults to _proto.
+	Name string
+	// Deterministic is used to marshal messages deterministically, with map entries sorted by key.
+	Deterministic bool
+	// Prefer is used to decode messages from the field when it is set, instead of from the other fields.
+	Prefer bool
+}
+
+// defaultRawMessageName is the name of the field of the raw message, when no name is configured.
+const defaultRawMessageName = "_proto"
+
+func (r *RawMessage) name() string {
+	if r.Name == "" {
+		return defaultRawMessageName
+	}
+	return r.Name
+}
+
+// withRoot returns the options for the messages of the root message desc.
+func (o SchemaOptions) withRoot(desc protoreflect.MessageDescriptor) SchemaOptions {
+	o.rootMessage = desc.FullName()
+	return o
+}
+
+// rawMessage reports whether the record of the message has the field of the raw message.
+// The raw message holds all fields of the message, and is not added when a FieldMask is used.
+// Records of the root message nested in the root message have the field, since they are the same
+// Avro record, but it is only set for the root message.
+func (o SchemaOptions) rawMessage(desc protoreflect.MessageDescriptor) bool {
+	return o.RawMessage != nil && len(o.FieldMask.GetPaths()) == 0 && desc.FullName() == o.rootMessage
+}
+
+// isRawMessageField reports whether the name is the name of the field of the raw message, which is
+// skipped when decoding the fields of messages.
+func (o *SchemaOptions) isRawMessageField(desc protoreflect.MessageDescriptor, name string) bool {
+	if name != defaultRawMessageName && (o.RawMessage == nil || name != o.RawMessage.name()) {
+		return false
+	}
+	return desc.Fields().ByName(protoreflect.Name(name)) == nil
+}
+
+func (o SchemaOptions) schemaRawMessage() avro.Field {
+	return avro.Field{
+		Name:    o.RawMessage.name(),
+		Doc:     "The message in protobuf wire format.",
+		Type:    avro.Nullable(avro.Bytes()),
+		Default: avro.NullDefault,
+	}
+}
+
+func (o SchemaOptions) encodeRawMessage(message protoreflect.Message, recursiveIndex int) (interface{}, error) {
+	if recursiveIndex > 0 {
+		return nil, nil
+	}
+	if o.EncodeTransformer != nil {
+		// encode the message as the fields of the record are encoded
+		transformed := proto.Clone(message.Interface()).ProtoReflect()
+		if err := o.transformMessage(transformed); err != nil {
+			return nil, err
+		}
+		message = transformed
+	}
+	// dropped required message fields are empty messages, without their required fields
+	raw, err := proto.MarshalOptions{
+		Deterministic: o.RawMessage.Deterministic,
+		AllowPartial:  o.EncodeTransformer != nil,
+	}.Marshal(message.Interface())
+	if err != nil {
+		return nil, fmt.Errorf("raw message %s: %w", message.Descriptor().FullName(), err)
+	}
+	return o.unionValue("bytes", raw), nil
+}
+
+// transformMessage applies the EncodeTransformer to the fields of the message and the messages it
+// contains, as when encoding them. Dropped required fields are set to zero values, as in the record.
+// Unknown fields can not be transformed and are cleared.
+func (o SchemaOptions) transformMessage(message protoreflect.Message) error {
+	if o.isWKT(message.Descriptor().FullName()) {
+		return nil
+	}
+	message.SetUnknown(nil)
+	var fields []protoreflect.FieldDescriptor
+	message.Range(func(field protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+		fields = append(fields, field)
+		return true
+	})
+	for _, field := range fields {
+		value, ok, err := o.EncodeTransformer(field, message.Get(field))
+		if err != nil {
+			return fmt.Errorf("transform field %s: %w", field.FullName(), err)
+		}
+		if !ok && isRequired(field) {
+			message.Set(field, o.zeroValue(message, field))
+			continue
+		}
+		if !ok {
+			message.Clear(field)
+			continue
+		}
+		message.Set(field, value)
+		if err := o.transformFieldMessages(field, message.Get(field)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o SchemaOptions) transformFieldMessages(field protoreflect.FieldDescriptor, value protoreflect.Value) error {
+	switch {
+	case field.IsMap():
+		if field.MapValue().Message() == nil {
+			return nil
+		}
+		var err error
+		value.Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+			err = o.transformMessage(v.Message())
+			return err == nil
+		})
+		return err
+	case field.Message() == nil:
+		return nil
+	case field.IsList():
+		for i := 0; i < value.List().Len(); i++ {
+			if err := o.transformMessage(value.List().Get(i).Message()); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return o.transformMessage(value.Message())
+	}
+}
+
+// decodeRawMessage decodes the root message from the raw message in data, when it is preferred and set,
+// and reports whether it was decoded.
+func (o *SchemaOptions) decodeRawMessage(data interface{}, message protoreflect.Message) (bool, error) {
+	if o.RawMessage == nil || !o.RawMessage.Prefer || len(o.FieldMask.GetPaths()) != 0 {
+		return false, nil
+	}
+	record, ok := data.(map[string]interface{})
+	if !ok {
+		return false, nil
+	}
+	if msgData, ok := o.unwrapMessage(record, message.Descriptor()); ok {
+		if record, ok = msgData.(map[string]interface{}); !ok {
+			return false, nil
+		}
+	}
+	value := record[o.RawMessage.name()]
+	if value == nil {
+		return false, nil
+	}
+	raw, err := decodeBytesLike(value, "bytes")
+	if err != nil {
+		return false, fmt.Errorf("raw message %s: %w", message.Descriptor().FullName(), err)
+	}
+	unmarshal := proto.UnmarshalOptions{Merge: true}
+	if o.Extensions != nil {
+		unmarshal.Resolver = o.Extensions
+	}
+	if err := unmarshal.Unmarshal(raw, message.Interface()); err != nil {
+		return false, fmt.Errorf("raw message %s: %w", message.Descriptor().FullName(), err)
+	}
+	return true, nil
+}
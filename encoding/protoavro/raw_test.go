@@ -0,0 +1,130 @@
+package protoavro
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"testing"
+
+	"go.einride.tech/protobuf-avro/avro"
+	examplev1 "go.einride.tech/protobuf-avro/internal/examples/proto/gen/einride/avro/example/v1"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"gotest.tools/v3/assert"
+)
+
+func Test_RawMessage(t *testing.T) {
+	newMessage := func() *examplev1.ExampleList {
+		msg := &examplev1.ExampleList{
+			Int64List: []int64{1, 2},
+			EnumList:  []examplev1.ExampleList_Enum{examplev1.ExampleList_ENUM_VALUE1, 99},
+			FloatValueList: []*wrapperspb.FloatValue{
+				wrapperspb.Float(math.Float32frombits(0x7fc00001)),
+			},
+		}
+		unknown := protowire.AppendTag(nil, 100, protowire.VarintType)
+		msg.ProtoReflect().SetUnknown(protowire.AppendVarint(unknown, 1))
+		return msg
+	}
+	roundtrip := func(t *testing.T, write, read SchemaOptions, msg proto.Message) *examplev1.ExampleList {
+		t.Helper()
+		got := &examplev1.ExampleList{}
+		roundtripInto(t, write, read, msg, got)
+		return got
+	}
+
+	t.Run("schema", func(t *testing.T) {
+		opts := SchemaOptions{OmitRootElement: true, RawMessage: &RawMessage{Name: "raw"}}
+		schema, err := opts.InferSchema((&examplev1.ExampleList{}).ProtoReflect().Descriptor())
+		assert.NilError(t, err)
+		fields := schema.(avro.Record).Fields
+		assert.Equal(t, "raw", fields[len(fields)-1].Name)
+		assert.DeepEqual(t, avro.Nullable(avro.Bytes()), fields[len(fields)-1].Type)
+	})
+
+	t.Run("prefer raw message", func(t *testing.T) {
+		opts := SchemaOptions{RawMessage: &RawMessage{Deterministic: true, Prefer: true}}
+		msg := newMessage()
+		got := roundtrip(t, opts, opts, msg)
+		assert.Equal(t, examplev1.ExampleList_Enum(99), got.GetEnumList()[1])
+		assert.Equal(t, uint32(0x7fc00001), math.Float32bits(got.GetFloatValueList()[0].GetValue()))
+		expected, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+		assert.NilError(t, err)
+		actual, err := proto.MarshalOptions{Deterministic: true}.Marshal(got)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, expected, actual)
+	})
+
+	t.Run("ignore raw message", func(t *testing.T) {
+		opts := SchemaOptions{RawMessage: &RawMessage{}}
+		got := roundtrip(t, opts, opts, newMessage())
+		assert.DeepEqual(t, []examplev1.ExampleList_Enum{
+			examplev1.ExampleList_ENUM_VALUE1,
+			examplev1.ExampleList_ENUM_UNSPECIFIED,
+		}, got.GetEnumList())
+		assert.Equal(t, 0, len(got.ProtoReflect().GetUnknown()))
+	})
+
+	t.Run("field mask", func(t *testing.T) {
+		opts := SchemaOptions{
+			OmitRootElement: true,
+			RawMessage:      &RawMessage{Prefer: true},
+			FieldMask:       &fieldmaskpb.FieldMask{Paths: []string{"int64_list"}},
+		}
+		schema, err := opts.InferSchema((&examplev1.ExampleList{}).ProtoReflect().Descriptor())
+		assert.NilError(t, err)
+		assert.Equal(t, 1, len(schema.(avro.Record).Fields))
+		got := roundtrip(t, opts, opts, newMessage())
+		assert.DeepEqual(t, &examplev1.ExampleList{Int64List: []int64{1, 2}}, got, protocmp.Transform())
+	})
+	t.Run("default unmarshaler", func(t *testing.T) {
+		got := roundtrip(t, SchemaOptions{RawMessage: &RawMessage{}}, SchemaOptions{}, newMessage())
+		assert.DeepEqual(t, []int64{1, 2}, got.GetInt64List())
+	})
+
+	t.Run("recursive", func(t *testing.T) {
+		opts := SchemaOptions{OmitRootElement: true, RawMessage: &RawMessage{}}
+		msg := &examplev1.ExampleRecursive{Recursive: &examplev1.ExampleRecursive{}}
+		data, err := opts.encodeJSON(msg)
+		assert.NilError(t, err)
+		record := data.(map[string]interface{})
+		assert.Assert(t, record[defaultRawMessageName] != nil)
+		nested := record["recursive"].(map[string]interface{})["einride.avro.example.v1.ExampleRecursive"]
+		assert.Assert(t, nested.(map[string]interface{})[defaultRawMessageName] == nil)
+		got := &examplev1.ExampleRecursive{}
+		roundtripInto(t, opts, SchemaOptions{RawMessage: &RawMessage{Prefer: true}}, msg, got)
+		assert.DeepEqual(t, msg, got, protocmp.Transform())
+	})
+
+	t.Run("encode transformer", func(t *testing.T) {
+		opts := SchemaOptions{
+			RawMessage:        &RawMessage{Prefer: true},
+			EncodeTransformer: HashRedactedFields([]byte("salt")),
+		}
+		msg := &examplev1.ExampleRedact{Name: "name", Email: "name@example.com", Age: 42}
+		msg.ProtoReflect().SetUnknown(protowire.AppendVarint(protowire.AppendTag(nil, 100, protowire.VarintType), 1))
+		got := &examplev1.ExampleRedact{}
+		roundtripInto(t, opts, opts, msg, got)
+		emailHash := sha256.Sum256([]byte("saltname@example.com"))
+		assert.DeepEqual(t, &examplev1.ExampleRedact{
+			Name:  "name",
+			Email: hex.EncodeToString(emailHash[:]),
+		}, got, protocmp.Transform())
+	})
+}
+
+func roundtripInto(t *testing.T, write, read SchemaOptions, msg, got proto.Message) {
+	t.Helper()
+	var b bytes.Buffer
+	marshaler, err := write.NewMarshaler(msg.ProtoReflect().Descriptor(), &b)
+	assert.NilError(t, err)
+	assert.NilError(t, marshaler.Marshal(msg))
+	unmarshaler, err := read.NewUnmarshaler(&b)
+	assert.NilError(t, err)
+	assert.Assert(t, unmarshaler.Scan())
+	assert.NilError(t, unmarshaler.Unmarshal(got))
+}
@@ -0,0 +1,85 @@
+package protoavro
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// NewReader returns a new reader that reads protobuf messages of type T from r in
+// Avro binary format.
+// T must be a generated message type, such as *library.Book.
+func NewReader[T proto.Message](r io.Reader, opts SchemaOptions) (*Reader[T], error) {
+	mt, err := messageType[T]()
+	if err != nil {
+		return nil, err
+	}
+	u, err := opts.NewUnmarshaler(r)
+	if err != nil {
+		return nil, fmt.Errorf("new unmarshaler: %w", err)
+	}
+	return &Reader[T]{mt: mt, u: u}, nil
+}
+
+// Reader reads and decodes Avro binary encoded messages of type T.
+type Reader[T proto.Message] struct {
+	mt protoreflect.MessageType
+	u  *Unmarshaler
+}
+
+// Next reads and returns the next message.
+// Next returns io.EOF when there are no more messages to be read.
+func (r *Reader[T]) Next() (T, error) {
+	var message T
+	if !r.u.Scan() {
+		if err := r.u.r.Err(); err != nil {
+			return message, fmt.Errorf("scan: %w", err)
+		}
+		return message, io.EOF
+	}
+	message = r.mt.New().Interface().(T)
+	if err := r.u.Unmarshal(message); err != nil {
+		var zero T
+		return zero, err
+	}
+	return message, nil
+}
+
+// All returns an iterator over the remaining messages.
+// Iteration stops after the first error.
+func (r *Reader[T]) All() func(yield func(T, error) bool) {
+	return func(yield func(T, error) bool) {
+		for {
+			message, err := r.Next()
+			if err == io.EOF {
+				return
+			}
+			if !yield(message, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// messageType returns the message type of T, from the zero value of T.
+func messageType[T proto.Message]() (mt protoreflect.MessageType, err error) {
+	var message T
+	name := reflect.TypeOf(&message).Elem().String()
+	if any(message) == nil {
+		return nil, fmt.Errorf("message type of %s: not a concrete message type", name)
+	}
+	defer func() {
+		// the zero values of message types without static descriptors, such as dynamic messages, panic
+		if r := recover(); r != nil {
+			mt, err = nil, fmt.Errorf("message type of %s: no descriptor for the zero value", name)
+		}
+	}()
+	mt = message.ProtoReflect().Type()
+	if mt == nil {
+		return nil, fmt.Errorf("message type of %s: no descriptor for the zero value", name)
+	}
+	return mt, nil
+}
@@ -0,0 +1,53 @@
+package protoavro
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// DropRedactedFields is a FieldTransformer that drops fields marked with the debug_redact option.
+func DropRedactedFields(field protoreflect.FieldDescriptor, value protoreflect.Value) (protoreflect.Value, bool, error) {
+	if isRedacted(field) {
+		return protoreflect.Value{}, false, nil
+	}
+	return value, true, nil
+}
+
+// HashRedactedFields returns a FieldTransformer that replaces singular string and bytes fields
+// marked with the debug_redact option with their salted SHA-256 hash. Strings are replaced with
+// the hex encoded hash. Other fields marked with the debug_redact option are dropped.
+// Hashes are not valid values of fields mapped to uuid, or to fixed types of another size than
+// the hash, so encoding fails for such fields.
+func HashRedactedFields(salt []byte) FieldTransformer {
+	return func(field protoreflect.FieldDescriptor, value protoreflect.Value) (protoreflect.Value, bool, error) {
+		if !isRedacted(field) {
+			return value, true, nil
+		}
+		if field.IsList() || field.IsMap() {
+			return protoreflect.Value{}, false, nil
+		}
+		switch field.Kind() {
+		case protoreflect.StringKind:
+			sum := saltedHash(salt, []byte(value.String()))
+			return protoreflect.ValueOfString(hex.EncodeToString(sum)), true, nil
+		case protoreflect.BytesKind:
+			return protoreflect.ValueOfBytes(saltedHash(salt, value.Bytes())), true, nil
+		}
+		return protoreflect.Value{}, false, nil
+	}
+}
+
+func isRedacted(field protoreflect.FieldDescriptor) bool {
+	options, ok := field.Options().(*descriptorpb.FieldOptions)
+	return ok && options.GetDebugRedact()
+}
+
+func saltedHash(salt []byte, data []byte) []byte {
+	h := sha256.New()
+	_, _ = h.Write(salt)
+	_, _ = h.Write(data)
+	return h.Sum(nil)
+}
@@ -0,0 +1,140 @@
+package protoavro
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	examplev1 "go.einride.tech/protobuf-avro/internal/examples/proto/gen/einride/avro/example/v1"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/testing/protocmp"
+	"gotest.tools/v3/assert"
+)
+
+func Test_FieldTransformer(t *testing.T) {
+	msg := &examplev1.ExampleRedact{
+		Name:  "name",
+		Email: "name@example.com",
+		Token: []byte("token"),
+		Age:   42,
+	}
+	emailHash := sha256.Sum256([]byte("saltname@example.com"))
+	tokenHash := sha256.Sum256([]byte("salttoken"))
+	for _, tt := range []struct {
+		name     string
+		opts     SchemaOptions
+		expected map[string]interface{}
+	}{
+		{
+			name: "drop redacted fields",
+			opts: SchemaOptions{
+				OmitRootElement:   true,
+				EncodeTransformer: DropRedactedFields,
+			},
+			expected: map[string]interface{}{
+				"name":  map[string]interface{}{"string": "name"},
+				"email": nil,
+				"token": nil,
+				"age":   nil,
+			},
+		},
+		{
+			name: "hash redacted fields",
+			opts: SchemaOptions{
+				OmitRootElement:   true,
+				EncodeTransformer: HashRedactedFields([]byte("salt")),
+			},
+			expected: map[string]interface{}{
+				"name":  map[string]interface{}{"string": "name"},
+				"email": map[string]interface{}{"string": hex.EncodeToString(emailHash[:])},
+				"token": map[string]interface{}{"bytes": tokenHash[:]},
+				"age":   nil,
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.opts.encodeJSON(msg)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tt.expected, got)
+		})
+	}
+
+	t.Run("decode", func(t *testing.T) {
+		data, err := SchemaOptions{OmitRootElement: true}.encodeJSON(msg)
+		assert.NilError(t, err)
+		opts := SchemaOptions{OmitRootElement: true, DecodeTransformer: DropRedactedFields}
+		var got examplev1.ExampleRedact
+		assert.NilError(t, opts.decodeJSON(data, &got))
+		assert.DeepEqual(t, &examplev1.ExampleRedact{Name: "name"}, &got, protocmp.Transform())
+	})
+
+	t.Run("error", func(t *testing.T) {
+		opts := SchemaOptions{
+			EncodeTransformer: func(
+				field protoreflect.FieldDescriptor,
+				value protoreflect.Value,
+			) (protoreflect.Value, bool, error) {
+				return value, true, errors.New("boom")
+			},
+		}
+		_, err := opts.encodeJSON(msg)
+		assert.ErrorContains(t, err, "transform field einride.avro.example.v1.ExampleRedact.name: boom")
+	})
+	t.Run("fixed and uuid fields", func(t *testing.T) {
+		for _, tt := range []struct {
+			name        string
+			opts        SchemaOptions
+			errContains string
+		}{
+			{
+				name: "hash fixed",
+				opts: SchemaOptions{
+					EncodeTransformer: HashRedactedFields([]byte("salt")),
+					FixedSizes:        map[protoreflect.FullName]int{"einride.avro.example.v1.ExampleRedact.token": 16},
+				},
+				errContains: "field token: expected 16 bytes, got 32",
+			},
+			{
+				name: "hash fixed of hash size",
+				opts: SchemaOptions{
+					EncodeTransformer: HashRedactedFields([]byte("salt")),
+					FixedSizes:        map[protoreflect.FullName]int{"einride.avro.example.v1.ExampleRedact.token": 32},
+				},
+			},
+			{
+				name: "hash uuid",
+				opts: SchemaOptions{
+					EncodeTransformer: HashRedactedFields([]byte("salt")),
+					UUIDCallback:      UUIDFields("einride.avro.example.v1.ExampleRedact.email"),
+				},
+				errContains: "field email: invalid uuid",
+			},
+			{
+				name: "drop",
+				opts: SchemaOptions{
+					EncodeTransformer: DropRedactedFields,
+					FixedSizes:        map[protoreflect.FullName]int{"einride.avro.example.v1.ExampleRedact.token": 16},
+					UUIDCallback:      UUIDFields("einride.avro.example.v1.ExampleRedact.email"),
+				},
+			},
+		} {
+			tt := tt
+			t.Run(tt.name, func(t *testing.T) {
+				msg := &examplev1.ExampleRedact{
+					Email: "123e4567-e89b-12d3-a456-426614174000",
+					Token: make([]byte, tt.opts.FixedSizes["einride.avro.example.v1.ExampleRedact.token"]),
+				}
+				_, err := tt.opts.InferSchema(msg.ProtoReflect().Descriptor())
+				assert.NilError(t, err)
+				_, err = tt.opts.encodeJSON(msg)
+				if tt.errContains != "" {
+					assert.ErrorContains(t, err, tt.errContains)
+					return
+				}
+				assert.NilError(t, err)
+			})
+		}
+	})
+}
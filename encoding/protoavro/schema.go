@@ -10,21 +10,42 @@ import (
 
 // InferSchema returns the Avro schema, with default SchemaOptions, for the protobuf message descriptor.
 func InferSchema(desc protoreflect.MessageDescriptor) (avro.Schema, error) {
-	return SchemaOptions{}.newSchemaInferrer().inferMessageSchema(desc, 0)
+	return SchemaOptions{}.InferSchema(desc)
 }
 
 // InferSchema returns the Avro schema for the protobuf message descriptor.
 func (o SchemaOptions) InferSchema(desc protoreflect.MessageDescriptor) (avro.Schema, error) {
-	return o.newSchemaInferrer().inferMessageSchema(desc, 0)
+	mask, err := newFieldMask(o.FieldMask, desc)
+	if err != nil {
+		return nil, err
+	}
+	return o.withRoot(desc).newSchemaInferrer().inferMessageSchema(desc, mask, 0)
 }
 
 type schemaInferrer struct {
 	opts SchemaOptions
-	seen map[string]struct{}
+	// seen maps the full names of inferred named types to
+	// the field mask they were inferred with.
+	seen map[string]string
+	// ids maps the field IDs assigned in the schema to the full names of their fields.
+	ids map[int]protoreflect.FullName
 }
 
 func (o SchemaOptions) newSchemaInferrer() schemaInferrer {
-	return schemaInferrer{seen: make(map[string]struct{}), opts: o}
+	return schemaInferrer{seen: make(map[string]string), opts: o, ids: make(map[int]protoreflect.FullName)}
+}
+
+// assignID returns the ID of the field, or zero when FieldIDs is not set,
+// and an error when the ID is already assigned to another field.
+func (s schemaInferrer) assignID(id int, name protoreflect.FullName) (int, error) {
+	if !s.opts.FieldIDs {
+		return 0, nil
+	}
+	if previous, ok := s.ids[id]; ok && previous != name {
+		return 0, fmt.Errorf("field ID %d of '%s' is also the ID of '%s'", id, name, previous)
+	}
+	s.ids[id] = name
+	return id, nil
 }
 
 func (s schemaInferrer) getDocs(desc protoreflect.Descriptor) string {
@@ -34,9 +55,141 @@ func (s schemaInferrer) getDocs(desc protoreflect.Descriptor) string {
 	return desc.ParentFile().SourceLocations().ByDescriptor(desc).LeadingComments
 }
 
+func (s schemaInferrer) getAliases(desc protoreflect.Descriptor) ([]string, error) {
+	if s.opts.AliasesCallback == nil {
+		return nil, nil
+	}
+	aliases := s.opts.AliasesCallback(desc)
+	for _, alias := range aliases {
+		// aliases of named types may be full names
+		var ns string
+		name := alias
+		if i := strings.LastIndex(alias, "."); i >= 0 {
+			ns, name = alias[:i], alias[i+1:]
+		}
+		if err := checkNames(ns, name, nil); err != nil {
+			return nil, fmt.Errorf("alias: %w", err)
+		}
+	}
+	return aliases, nil
+}
+
+func (s schemaInferrer) getProps(desc protoreflect.Descriptor) map[string]interface{} {
+	var props map[string]interface{}
+	if s.opts.PropsCallback != nil {
+		props = s.opts.PropsCallback(desc)
+	}
+	var metadata map[string]interface{}
+	if s.opts.ProtoMetadata {
+		metadata = protoMetadata(desc)
+	} else if field, ok := desc.(protoreflect.FieldDescriptor); ok {
+		// renamed fields are matched by their numbers when decoding
+		metadata = map[string]interface{}{fieldNumberProp: int(field.Number())}
+	}
+	if len(metadata) == 0 {
+		return props
+	}
+	for name, value := range props {
+		if _, ok := metadata[name]; !ok {
+			metadata[name] = value
+		}
+	}
+	return metadata
+}
+
+// protoMetadata returns the properties that trace the schema of a field, message or enum
+// back to its protobuf definition.
+func protoMetadata(desc protoreflect.Descriptor) map[string]interface{} {
+	switch d := desc.(type) {
+	case protoreflect.FieldDescriptor:
+		return map[string]interface{}{
+			fieldNumberProp: int(d.Number()),
+			"proto.type":    protoType(d),
+		}
+	case protoreflect.MessageDescriptor, protoreflect.EnumDescriptor:
+		return map[string]interface{}{
+			"proto.full_name": string(d.FullName()),
+			"proto.file":      d.ParentFile().Path(),
+		}
+	}
+	return nil
+}
+
+// protoType returns the type of the field as written in proto files, with the full names of
+// messages and enums, without the label of repeated fields.
+func protoType(field protoreflect.FieldDescriptor) string {
+	if field.IsMap() {
+		return fmt.Sprintf("map<%s, %s>", protoType(field.MapKey()), protoType(field.MapValue()))
+	}
+	switch field.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return string(field.Message().FullName())
+	case protoreflect.EnumKind:
+		return string(field.Enum().FullName())
+	}
+	return field.Kind().String()
+}
+
+func (s schemaInferrer) getDefault(field protoreflect.FieldDescriptor, schema avro.Schema) interface{} {
+	if s.opts.DefaultCallback != nil {
+		if value, ok := s.opts.DefaultCallback(field); ok {
+			return value
+		}
+	}
+	if value, ok := s.opts.declaredDefault(field); ok {
+		return value
+	}
+	if isRequired(field) {
+		// readers fail on records without required fields
+		return nil
+	}
+	return defaultValue(schema)
+}
+
+// defaultValue returns the default value of a field with the schema: null for nullable unions,
+// and the zero value for other types. Records have no default value.
+func defaultValue(schema avro.Schema) interface{} {
+	switch s := schema.(type) {
+	case avro.Union:
+		if len(s) == 0 {
+			return nil
+		}
+		if p, ok := s[0].(avro.Primitive); ok && p.Type == avro.NullType {
+			return avro.NullDefault
+		}
+		// defaults of unions are values of the first branch
+		return defaultValue(s[0])
+	case avro.Primitive:
+		switch s.Type {
+		case avro.NullType:
+			return avro.NullDefault
+		case avro.BooleanType:
+			return false
+		case avro.IntType, avro.LongType, avro.FloatType, avro.DoubleType:
+			return 0
+		case avro.StringType, avro.BytesType:
+			return ""
+		}
+	case avro.Enum:
+		if s.Default != "" {
+			return s.Default
+		}
+		if len(s.Symbols) > 0 {
+			return s.Symbols[0]
+		}
+	case avro.Array:
+		return []interface{}{}
+	case avro.Map:
+		return map[string]interface{}{}
+	case avro.Fixed:
+		return strings.Repeat("\u0000", s.Size)
+	}
+	return nil
+}
+
 func (s schemaInferrer) maybeNullableArray(schema avro.Schema) avro.Schema {
 	u := avro.Nullable(schema)
-	if s.opts.OmitNullArray {
+	if s.opts.omitNullArray() {
 		return u[1]
 	}
 	return u
@@ -44,47 +197,94 @@ func (s schemaInferrer) maybeNullableArray(schema avro.Schema) avro.Schema {
 
 func (s schemaInferrer) inferMessageSchema(
 	message protoreflect.MessageDescriptor,
+	mask fieldMask,
 	recursiveIndex int,
 ) (avro.Schema, error) {
-	if isWKT(message.FullName()) {
-		return schemaWKT(message)
+	if s.opts.isWKT(message.FullName()) {
+		return s.schemaWKT(message, recursiveIndex)
 	}
 
-	n := string(message.Name())
-	ns := namespace(message)
+	n := s.opts.avroName(message)
+	ns := s.opts.avroNamespace(message)
 	fullName := fmt.Sprintf("%s.%s", ns, n)
 
-	if _, ok := s.seen[fullName]; ok {
+	if seenMask, ok := s.seen[fullName]; ok {
+		if seenMask != mask.String() {
+			return nil, fmt.Errorf("message '%s' is selected by conflicting field masks", fullName)
+		}
 		return avro.Nullable(avro.Reference(fullName)), nil
 	}
 
-	s.seen[fullName] = struct{}{}
+	s.seen[fullName] = mask.String()
+	fieldNames := s.opts.fieldNames(message)
+	names := fieldNames
+	if s.opts.preserveUnknown(message, mask) {
+		names = append(names[:len(names):len(names)], unknownFieldName)
+	}
+	if s.opts.rawMessage(message) {
+		names = append(names[:len(names):len(names)], s.opts.RawMessage.name())
+	}
+	if err := checkNames(ns, n, names); err != nil {
+		return nil, fmt.Errorf("message '%s': %w", message.FullName(), err)
+	}
+	aliases, err := s.getAliases(message)
+	if err != nil {
+		return nil, fmt.Errorf("message '%s': %w", message.FullName(), err)
+	}
 	doc := s.getDocs(message)
 	record := avro.Record{
 		Type:      avro.RecordType,
 		Doc:       doc,
 		Name:      n,
 		Namespace: ns,
+		Aliases:   aliases,
 		Fields:    make([]avro.Field, 0, message.Fields().Len()),
+		Props:     s.getProps(message),
 	}
-	for i := 0; i < message.Fields().Len(); i++ {
-		field := message.Fields().Get(i)
-		fieldSchema, err := s.inferField(field, recursiveIndex+1)
+	for i, field := range s.opts.recordFields(message) {
+		fieldMask, ok := mask.sub(field)
+		if !ok {
+			continue
+		}
+		fieldID, err := s.assignID(s.opts.fieldID(field), field.FullName())
+		if err != nil {
+			return nil, err
+		}
+		fieldSchema, err := s.inferField(field, fieldMask, recursiveIndex+1)
 		if err != nil {
 			return nil, err
 		}
 
-		if field.IsList() && s.opts.OmitNullArray {
-
-		} else {
+		switch {
+		case isRequired(field):
+			fieldSchema.Type = nonNullable(fieldSchema.Type)
+		case field.IsList() && s.opts.omitNullArray():
+		case field.HasDefault():
+			// defaults of unions are values of the first branch
+			fieldSchema.Type = avro.Union{nonNullable(fieldSchema.Type), avro.Null()}
+		default:
 			fieldSchema.Type = avro.Nullable(fieldSchema.Type)
 		}
+		fieldSchema.Name = fieldNames[i]
+		fieldSchema.FieldID = fieldID
+		fieldSchema.Props = s.getProps(field)
+		fieldSchema.Aliases, err = s.getAliases(field)
+		if err != nil {
+			return nil, fmt.Errorf("field '%s': %w", field.FullName(), err)
+		}
+		fieldSchema.Default = s.getDefault(field, fieldSchema.Type)
 
 		record.Fields = append(
 			record.Fields,
 			fieldSchema,
 		)
 	}
+	if s.opts.preserveUnknown(message, mask) {
+		record.Fields = append(record.Fields, schemaUnknown())
+	}
+	if s.opts.rawMessage(message) {
+		record.Fields = append(record.Fields, s.opts.schemaRawMessage())
+	}
 	if message.IsMapEntry() {
 		return record, nil
 	}
@@ -98,7 +298,11 @@ func namespace(desc protoreflect.Descriptor) string {
 	return strings.TrimSuffix(string(desc.FullName()), "."+string(desc.Name()))
 }
 
-func (s schemaInferrer) inferField(field protoreflect.FieldDescriptor, recursiveIndex int) (avro.Field, error) {
+func (s schemaInferrer) inferField(
+	field protoreflect.FieldDescriptor,
+	mask fieldMask,
+	recursiveIndex int,
+) (avro.Field, error) {
 	doc := s.getDocs(field)
 
 	if field.IsMap() {
@@ -112,18 +316,24 @@ func (s schemaInferrer) inferField(field protoreflect.FieldDescriptor, recursive
 			Type: mapType,
 		}, nil
 	}
-	fieldKind, err := s.inferFieldKind(field, recursiveIndex)
+	fieldKind, err := s.inferFieldKind(field, mask, recursiveIndex)
 	if err != nil {
 		return avro.Field{}, err
 	}
 	if field.IsList() {
+		id, err := s.assignID(elementID(field), field.FullName()+"[]")
+		if err != nil {
+			return avro.Field{}, err
+		}
+		array := avro.Array{
+			Type:      avro.ArrayType,
+			Items:     s.maybeNullableArray(fieldKind),
+			ElementID: id,
+		}
 		return avro.Field{
 			Name: string(field.Name()),
 			Doc:  doc,
-			Type: avro.Array{
-				Type:  avro.ArrayType,
-				Items: s.maybeNullableArray(fieldKind),
-			},
+			Type: array,
 		}, nil
 	}
 	if oneof := field.ContainingOneof(); oneof != nil {
@@ -153,7 +363,11 @@ func oneofDoc(doc string, oneof protoreflect.OneofDescriptor) string {
 	return fmt.Sprintf("%s\n\n%s", doc, oneofDoc)
 }
 
-func (s schemaInferrer) inferFieldKind(field protoreflect.FieldDescriptor, recursiveIndex int) (avro.Schema, error) {
+func (s schemaInferrer) inferFieldKind(
+	field protoreflect.FieldDescriptor,
+	mask fieldMask,
+	recursiveIndex int,
+) (avro.Schema, error) {
 	switch field.Kind() {
 	case protoreflect.DoubleKind:
 		return avro.Double(), nil
@@ -174,35 +388,36 @@ func (s schemaInferrer) inferFieldKind(field protoreflect.FieldDescriptor, recur
 	case protoreflect.BoolKind:
 		return avro.Boolean(), nil
 	case protoreflect.BytesKind:
+		if size, ok := s.opts.FixedSizes[field.FullName()]; ok {
+			return s.inferFixedSchema(field, size), nil
+		}
 		return avro.Bytes(), nil
 	case protoreflect.StringKind:
+		if s.opts.isUUID(field) {
+			return avro.UUID(), nil
+		}
 		return avro.String(), nil
 	case protoreflect.EnumKind:
-		return s.inferEnumSchema(field.Enum()), nil
+		return s.inferEnumSchema(field.Enum())
 	case protoreflect.MessageKind, protoreflect.GroupKind:
-		return s.inferMessageSchema(field.Message(), recursiveIndex)
+		return s.inferMessageSchema(field.Message(), mask, recursiveIndex)
 	}
 	return nil, fmt.Errorf("unsupported field kind %s %s", field.Name(), field.Kind())
 }
 
-func (s schemaInferrer) inferEnumSchema(enum protoreflect.EnumDescriptor) avro.Schema {
-	n := string(enum.Name())
-	ns := namespace(enum)
+func (s schemaInferrer) inferFixedSchema(field protoreflect.FieldDescriptor, size int) avro.Schema {
+	n := s.opts.avroName(field)
+	ns := s.opts.avroNamespace(field)
 	fullName := fmt.Sprintf("%s.%s", ns, n)
 
 	if _, ok := s.seen[fullName]; ok {
 		return avro.Reference(fullName)
 	}
-	s.seen[fullName] = struct{}{}
-	doc := s.getDocs(enum)
-	e := avro.Enum{
-		Type:      avro.EnumType,
-		Doc:       doc,
+	s.seen[fullName] = ""
+	return avro.Fixed{
+		Type:      avro.FixedType,
 		Name:      n,
 		Namespace: ns,
+		Size:      size,
 	}
-	for i := 0; i < enum.Values().Len(); i++ {
-		e.Symbols = append(e.Symbols, string(enum.Values().Get(i).Name()))
-	}
-	return e
 }
@@ -20,10 +20,30 @@ func ExampleInferSchema() {
 		Name:      "Book",
 		Namespace: "google.example.library.v1",
 		Fields: []avro.Field{
-			{Name: "name", Type: avro.Nullable(avro.String())},
-			{Name: "author", Type: avro.Nullable(avro.String())},
-			{Name: "title", Type: avro.Nullable(avro.String())},
-			{Name: "read", Type: avro.Nullable(avro.Boolean())},
+			{
+				Name:    "name",
+				Type:    avro.Nullable(avro.String()),
+				Default: avro.NullDefault,
+				Props:   map[string]interface{}{"proto.number": 1},
+			},
+			{
+				Name:    "author",
+				Type:    avro.Nullable(avro.String()),
+				Default: avro.NullDefault,
+				Props:   map[string]interface{}{"proto.number": 2},
+			},
+			{
+				Name:    "title",
+				Type:    avro.Nullable(avro.String()),
+				Default: avro.NullDefault,
+				Props:   map[string]interface{}{"proto.number": 3},
+			},
+			{
+				Name:    "read",
+				Type:    avro.Nullable(avro.Boolean()),
+				Default: avro.NullDefault,
+				Props:   map[string]interface{}{"proto.number": 4},
+			},
 		},
 	})
 	fmt.Println(cmp.Equal(expected, schema))
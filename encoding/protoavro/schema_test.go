@@ -1,12 +1,15 @@
 package protoavro
 
 import (
+	"encoding/json"
 	"testing"
 
 	"go.einride.tech/protobuf-avro/avro"
 	examplev1 "go.einride.tech/protobuf-avro/internal/examples/proto/gen/einride/avro/example/v1"
 	"google.golang.org/genproto/googleapis/example/library/v1"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/testing/protocmp"
 	"gotest.tools/v3/assert"
 )
 
@@ -25,10 +28,10 @@ func TestInferSchema(t *testing.T) {
 				Name:      "Book",
 				Namespace: "google.example.library.v1",
 				Fields: []avro.Field{
-					{Name: "name", Type: avro.Nullable(avro.String())},
-					{Name: "author", Type: avro.Nullable(avro.String())},
-					{Name: "title", Type: avro.Nullable(avro.String())},
-					{Name: "read", Type: avro.Nullable(avro.Boolean())},
+					{Name: "name", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(1)},
+					{Name: "author", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(2)},
+					{Name: "title", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(3)},
+					{Name: "read", Type: avro.Nullable(avro.Boolean()), Default: avro.NullDefault, Props: numberProps(4)},
 				},
 			}),
 		},
@@ -47,12 +50,14 @@ func TestInferSchema(t *testing.T) {
 							Name:      "Book",
 							Namespace: "google.example.library.v1",
 							Fields: []avro.Field{
-								{Name: "name", Type: avro.Nullable(avro.String())},
-								{Name: "author", Type: avro.Nullable(avro.String())},
-								{Name: "title", Type: avro.Nullable(avro.String())},
-								{Name: "read", Type: avro.Nullable(avro.Boolean())},
+								{Name: "name", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(1)},
+								{Name: "author", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(2)},
+								{Name: "title", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(3)},
+								{Name: "read", Type: avro.Nullable(avro.Boolean()), Default: avro.NullDefault, Props: numberProps(4)},
 							},
 						}),
+						Default: avro.NullDefault,
+						Props:   numberProps(1),
 					},
 					{
 						Name: "update_mask",
@@ -67,9 +72,13 @@ func TestInferSchema(t *testing.T) {
 										Type:  avro.ArrayType,
 										Items: avro.Nullable(avro.String()),
 									}),
+									Default: avro.NullDefault,
+									Props:   numberProps(1),
 								},
 							},
 						}),
+						Default: avro.NullDefault,
+						Props:   numberProps(2),
 					},
 				},
 			}),
@@ -87,6 +96,8 @@ func TestInferSchema(t *testing.T) {
 						Type: avro.Nullable(
 							avro.String(),
 						),
+						Default: avro.NullDefault,
+						Props:   numberProps(1),
 					},
 				},
 			}),
@@ -100,8 +111,10 @@ func TestInferSchema(t *testing.T) {
 				Namespace: "einride.avro.example.v1",
 				Fields: []avro.Field{
 					{
-						Name: "bytes",
-						Type: avro.Nullable(avro.Bytes()),
+						Name:    "bytes",
+						Type:    avro.Nullable(avro.Bytes()),
+						Default: avro.NullDefault,
+						Props:   numberProps(1),
 					},
 				},
 			}),
@@ -114,7 +127,7 @@ func TestInferSchema(t *testing.T) {
 				Name:      "ExampleDate",
 				Namespace: "einride.avro.example.v1",
 				Fields: []avro.Field{
-					{Name: "date", Type: avro.Nullable(avro.Date())},
+					{Name: "date", Type: avro.Nullable(avro.Date()), Default: avro.NullDefault, Props: numberProps(1)},
 				},
 			}),
 		},
@@ -133,17 +146,19 @@ func TestInferSchema(t *testing.T) {
 							Name:      "DateTime",
 							Namespace: "google.type",
 							Fields: []avro.Field{
-								{Name: "year", Type: avro.Nullable(avro.Integer())},
-								{Name: "month", Type: avro.Nullable(avro.Integer())},
-								{Name: "day", Type: avro.Nullable(avro.Integer())},
-								{Name: "hours", Type: avro.Nullable(avro.Integer())},
-								{Name: "minutes", Type: avro.Nullable(avro.Integer())},
-								{Name: "seconds", Type: avro.Nullable(avro.Integer())},
-								{Name: "nanos", Type: avro.Nullable(avro.Integer())},
+								{Name: "year", Type: avro.Nullable(avro.Integer()), Default: avro.NullDefault, Props: numberProps(1)},
+								{Name: "month", Type: avro.Nullable(avro.Integer()), Default: avro.NullDefault, Props: numberProps(2)},
+								{Name: "day", Type: avro.Nullable(avro.Integer()), Default: avro.NullDefault, Props: numberProps(3)},
+								{Name: "hours", Type: avro.Nullable(avro.Integer()), Default: avro.NullDefault, Props: numberProps(4)},
+								{Name: "minutes", Type: avro.Nullable(avro.Integer()), Default: avro.NullDefault, Props: numberProps(5)},
+								{Name: "seconds", Type: avro.Nullable(avro.Integer()), Default: avro.NullDefault, Props: numberProps(6)},
+								{Name: "nanos", Type: avro.Nullable(avro.Integer()), Default: avro.NullDefault, Props: numberProps(7)},
 								{
-									Name: "utc_offset",
-									Doc:  "At most one will be set:\n* utc_offset\n* time_zone",
-									Type: avro.Nullable(avro.Float()),
+									Name:    "utc_offset",
+									Doc:     "At most one will be set:\n* utc_offset\n* time_zone",
+									Type:    avro.Nullable(avro.Float()),
+									Default: avro.NullDefault,
+									Props:   numberProps(8),
 								},
 								{
 									Name: "time_zone",
@@ -153,13 +168,17 @@ func TestInferSchema(t *testing.T) {
 										Name:      "TimeZone",
 										Namespace: "google.type",
 										Fields: []avro.Field{
-											{Name: "id", Type: avro.Nullable(avro.String())},
-											{Name: "version", Type: avro.Nullable(avro.String())},
+											{Name: "id", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(1)},
+											{Name: "version", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(2)},
 										},
 									}),
+									Default: avro.NullDefault,
+									Props:   numberProps(9),
 								},
 							},
 						}),
+						Default: avro.NullDefault,
+						Props:   numberProps(1),
 					},
 				},
 			}),
@@ -172,7 +191,7 @@ func TestInferSchema(t *testing.T) {
 				Name:      "ExampleDuration",
 				Namespace: "einride.avro.example.v1",
 				Fields: []avro.Field{
-					{Name: "duration", Type: avro.Nullable(avro.Float())},
+					{Name: "duration", Type: avro.Nullable(avro.Float()), Default: avro.NullDefault, Props: numberProps(1)},
 				},
 			}),
 		},
@@ -196,7 +215,10 @@ func TestInferSchema(t *testing.T) {
 								"ENUM_VALUE2",
 								"ENUM_VALUE3",
 							},
+							Default: "ENUM_UNSPECIFIED",
 						}),
+						Default: avro.NullDefault,
+						Props:   numberProps(1),
 					},
 				},
 			}),
@@ -215,6 +237,8 @@ func TestInferSchema(t *testing.T) {
 							Type:  avro.ArrayType,
 							Items: avro.Nullable(avro.Long()),
 						}),
+						Default: avro.NullDefault,
+						Props:   numberProps(1),
 					},
 					{
 						Name: "string_list",
@@ -222,6 +246,8 @@ func TestInferSchema(t *testing.T) {
 							Type:  avro.ArrayType,
 							Items: avro.Nullable(avro.String()),
 						}),
+						Default: avro.NullDefault,
+						Props:   numberProps(2),
 					},
 					{
 						Name: "enum_list",
@@ -236,8 +262,11 @@ func TestInferSchema(t *testing.T) {
 									"ENUM_VALUE1",
 									"ENUM_VALUE2",
 								},
+								Default: "ENUM_UNSPECIFIED",
 							}),
 						}),
+						Default: avro.NullDefault,
+						Props:   numberProps(3),
 					},
 					{
 						Name: "nested_list",
@@ -254,10 +283,14 @@ func TestInferSchema(t *testing.T) {
 											Type:  avro.ArrayType,
 											Items: avro.Nullable(avro.String()),
 										}),
+										Default: avro.NullDefault,
+										Props:   numberProps(1),
 									},
 								},
 							}),
 						}),
+						Default: avro.NullDefault,
+						Props:   numberProps(4),
 					},
 					{
 						Name: "float_value_list",
@@ -265,6 +298,8 @@ func TestInferSchema(t *testing.T) {
 							Type:  avro.ArrayType,
 							Items: avro.Nullable(avro.Float()),
 						}),
+						Default: avro.NullDefault,
+						Props:   numberProps(5),
 					},
 				},
 			}),
@@ -286,11 +321,13 @@ func TestInferSchema(t *testing.T) {
 								Name:      "StringToStringEntry",
 								Namespace: "einride.avro.example.v1.ExampleMap",
 								Fields: []avro.Field{
-									{Name: "key", Type: avro.Nullable(avro.String())},
-									{Name: "value", Type: avro.Nullable(avro.String())},
+									{Name: "key", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(1)},
+									{Name: "value", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(2)},
 								},
 							},
 						}),
+						Default: avro.NullDefault,
+						Props:   numberProps(1),
 					},
 					{
 						Name: "string_to_nested",
@@ -301,7 +338,7 @@ func TestInferSchema(t *testing.T) {
 								Name:      "StringToNestedEntry",
 								Namespace: "einride.avro.example.v1.ExampleMap",
 								Fields: []avro.Field{
-									{Name: "key", Type: avro.Nullable(avro.String())},
+									{Name: "key", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(1)},
 									{
 										Name: "value",
 										Type: avro.Nullable(avro.Record{
@@ -318,18 +355,24 @@ func TestInferSchema(t *testing.T) {
 															Name:      "StringToStringEntry",
 															Namespace: "einride.avro.example.v1.ExampleMap.Nested",
 															Fields: []avro.Field{
-																{Name: "key", Type: avro.Nullable(avro.String())},
-																{Name: "value", Type: avro.Nullable(avro.String())},
+																{Name: "key", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(1)},
+																{Name: "value", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(2)},
 															},
 														},
 													}),
+													Default: avro.NullDefault,
+													Props:   numberProps(1),
 												},
 											},
 										}),
+										Default: avro.NullDefault,
+										Props:   numberProps(2),
 									},
 								},
 							},
 						}),
+						Default: avro.NullDefault,
+						Props:   numberProps(2),
 					},
 					{
 						Name: "string_to_enum",
@@ -340,7 +383,7 @@ func TestInferSchema(t *testing.T) {
 								Name:      "StringToEnumEntry",
 								Namespace: "einride.avro.example.v1.ExampleMap",
 								Fields: []avro.Field{
-									{Name: "key", Type: avro.Nullable(avro.String())},
+									{Name: "key", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(1)},
 									{
 										Name: "value",
 										Type: avro.Nullable(avro.Enum{
@@ -352,11 +395,16 @@ func TestInferSchema(t *testing.T) {
 												"ENUM_VALUE1",
 												"ENUM_VALUE2",
 											},
+											Default: "ENUM_UNSPECIFIED",
 										}),
+										Default: avro.NullDefault,
+										Props:   numberProps(2),
 									},
 								},
 							},
 						}),
+						Default: avro.NullDefault,
+						Props:   numberProps(3),
 					},
 					{
 						Name: "int32_to_string",
@@ -367,11 +415,13 @@ func TestInferSchema(t *testing.T) {
 								Name:      "Int32ToStringEntry",
 								Namespace: "einride.avro.example.v1.ExampleMap",
 								Fields: []avro.Field{
-									{Name: "key", Type: avro.Nullable(avro.Integer())},
-									{Name: "value", Type: avro.Nullable(avro.String())},
+									{Name: "key", Type: avro.Nullable(avro.Integer()), Default: avro.NullDefault, Props: numberProps(1)},
+									{Name: "value", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(2)},
 								},
 							},
 						}),
+						Default: avro.NullDefault,
+						Props:   numberProps(4),
 					},
 					{
 						Name: "int64_to_string",
@@ -382,11 +432,13 @@ func TestInferSchema(t *testing.T) {
 								Name:      "Int64ToStringEntry",
 								Namespace: "einride.avro.example.v1.ExampleMap",
 								Fields: []avro.Field{
-									{Name: "key", Type: avro.Nullable(avro.Long())},
-									{Name: "value", Type: avro.Nullable(avro.String())},
+									{Name: "key", Type: avro.Nullable(avro.Long()), Default: avro.NullDefault, Props: numberProps(1)},
+									{Name: "value", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(2)},
 								},
 							},
 						}),
+						Default: avro.NullDefault,
+						Props:   numberProps(5),
 					},
 					{
 						Name: "uint32_to_string",
@@ -397,11 +449,13 @@ func TestInferSchema(t *testing.T) {
 								Name:      "Uint32ToStringEntry",
 								Namespace: "einride.avro.example.v1.ExampleMap",
 								Fields: []avro.Field{
-									{Name: "key", Type: avro.Nullable(avro.Integer())},
-									{Name: "value", Type: avro.Nullable(avro.String())},
+									{Name: "key", Type: avro.Nullable(avro.Integer()), Default: avro.NullDefault, Props: numberProps(1)},
+									{Name: "value", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(2)},
 								},
 							},
 						}),
+						Default: avro.NullDefault,
+						Props:   numberProps(6),
 					},
 					{
 						Name: "bool_to_string",
@@ -412,11 +466,13 @@ func TestInferSchema(t *testing.T) {
 								Name:      "BoolToStringEntry",
 								Namespace: "einride.avro.example.v1.ExampleMap",
 								Fields: []avro.Field{
-									{Name: "key", Type: avro.Nullable(avro.Boolean())},
-									{Name: "value", Type: avro.Nullable(avro.String())},
+									{Name: "key", Type: avro.Nullable(avro.Boolean()), Default: avro.NullDefault, Props: numberProps(1)},
+									{Name: "value", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(2)},
 								},
 							},
 						}),
+						Default: avro.NullDefault,
+						Props:   numberProps(7),
 					},
 					{
 						Name: "string_to_float_value",
@@ -427,11 +483,13 @@ func TestInferSchema(t *testing.T) {
 								Name:      "StringToFloatValueEntry",
 								Namespace: "einride.avro.example.v1.ExampleMap",
 								Fields: []avro.Field{
-									{Name: "key", Type: avro.Nullable(avro.String())},
-									{Name: "value", Type: avro.Nullable(avro.Float())},
+									{Name: "key", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(1)},
+									{Name: "value", Type: avro.Nullable(avro.Float()), Default: avro.NullDefault, Props: numberProps(2)},
 								},
 							},
 						}),
+						Default: avro.NullDefault,
+						Props:   numberProps(8),
 					},
 				},
 			}),
@@ -453,16 +511,22 @@ func TestInferSchema(t *testing.T) {
 							Namespace: "einride.avro.example.v1.ExampleOneof",
 							Fields:    []avro.Field{},
 						}),
+						Default: avro.NullDefault,
+						Props:   numberProps(1),
 					},
 					{
-						Name: "oneof_bool_1",
-						Doc:  "At most one will be set:\n* oneof_empty_message_1\n* oneof_bool_1",
-						Type: avro.Nullable(avro.Boolean()),
+						Name:    "oneof_bool_1",
+						Doc:     "At most one will be set:\n* oneof_empty_message_1\n* oneof_bool_1",
+						Type:    avro.Nullable(avro.Boolean()),
+						Default: avro.NullDefault,
+						Props:   numberProps(2),
 					},
 					{
-						Name: "oneof_empty_message_2",
-						Doc:  "At most one will be set:\n* oneof_empty_message_2\n* oneof_message",
-						Type: avro.Nullable(avro.Reference("einride.avro.example.v1.ExampleOneof.EmptyMessage")),
+						Name:    "oneof_empty_message_2",
+						Doc:     "At most one will be set:\n* oneof_empty_message_2\n* oneof_message",
+						Type:    avro.Nullable(avro.Reference("einride.avro.example.v1.ExampleOneof.EmptyMessage")),
+						Default: avro.NullDefault,
+						Props:   numberProps(3),
 					},
 					{
 						Name: "oneof_message",
@@ -472,9 +536,11 @@ func TestInferSchema(t *testing.T) {
 							Name:      "Message",
 							Namespace: "einride.avro.example.v1.ExampleOneof",
 							Fields: []avro.Field{
-								{Name: "string_value", Type: avro.Nullable(avro.String())},
+								{Name: "string_value", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(1)},
 							},
 						}),
+						Default: avro.NullDefault,
+						Props:   numberProps(4),
 					},
 				},
 			}),
@@ -492,6 +558,8 @@ func TestInferSchema(t *testing.T) {
 						Type: avro.Nullable(
 							avro.Reference("einride.avro.example.v1.ExampleRecursive"),
 						),
+						Default: avro.NullDefault,
+						Props:   numberProps(1),
 					},
 				},
 			}),
@@ -509,6 +577,8 @@ func TestInferSchema(t *testing.T) {
 						Type: avro.Nullable(
 							avro.String(),
 						),
+						Default: avro.NullDefault,
+						Props:   numberProps(1),
 					},
 				},
 			}),
@@ -521,7 +591,7 @@ func TestInferSchema(t *testing.T) {
 				Name:      "ExampleTimestamp",
 				Namespace: "einride.avro.example.v1",
 				Fields: []avro.Field{
-					{Name: "timestamp", Type: avro.Nullable(avro.TimestampMicros())},
+					{Name: "timestamp", Type: avro.Nullable(avro.TimestampMicros()), Default: avro.NullDefault, Props: numberProps(1)},
 				},
 			}),
 		},
@@ -533,7 +603,7 @@ func TestInferSchema(t *testing.T) {
 				Name:      "ExampleTimeOfDay",
 				Namespace: "einride.avro.example.v1",
 				Fields: []avro.Field{
-					{Name: "time_of_day", Type: avro.Nullable(avro.TimeMicros())},
+					{Name: "time_of_day", Type: avro.Nullable(avro.TimeMicros()), Default: avro.NullDefault, Props: numberProps(1)},
 				},
 			}),
 		},
@@ -545,15 +615,15 @@ func TestInferSchema(t *testing.T) {
 				Name:      "ExampleWrappers",
 				Namespace: "einride.avro.example.v1",
 				Fields: []avro.Field{
-					{Name: "float_value", Type: avro.Nullable(avro.Float())},
-					{Name: "double_value", Type: avro.Nullable(avro.Double())},
-					{Name: "string_value", Type: avro.Nullable(avro.String())},
-					{Name: "bytes_value", Type: avro.Nullable(avro.Bytes())},
-					{Name: "int32_value", Type: avro.Nullable(avro.Integer())},
-					{Name: "int64_value", Type: avro.Nullable(avro.Long())},
-					{Name: "uint32_value", Type: avro.Nullable(avro.Integer())},
-					{Name: "uint64_value", Type: avro.Nullable(avro.Long())},
-					{Name: "bool_value", Type: avro.Nullable(avro.Boolean())},
+					{Name: "float_value", Type: avro.Nullable(avro.Float()), Default: avro.NullDefault, Props: numberProps(1)},
+					{Name: "double_value", Type: avro.Nullable(avro.Double()), Default: avro.NullDefault, Props: numberProps(2)},
+					{Name: "string_value", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(3)},
+					{Name: "bytes_value", Type: avro.Nullable(avro.Bytes()), Default: avro.NullDefault, Props: numberProps(4)},
+					{Name: "int32_value", Type: avro.Nullable(avro.Integer()), Default: avro.NullDefault, Props: numberProps(5)},
+					{Name: "int64_value", Type: avro.Nullable(avro.Long()), Default: avro.NullDefault, Props: numberProps(6)},
+					{Name: "uint32_value", Type: avro.Nullable(avro.Integer()), Default: avro.NullDefault, Props: numberProps(7)},
+					{Name: "uint64_value", Type: avro.Nullable(avro.Long()), Default: avro.NullDefault, Props: numberProps(8)},
+					{Name: "bool_value", Type: avro.Nullable(avro.Boolean()), Default: avro.NullDefault, Props: numberProps(9)},
 				},
 			}),
 		},
@@ -564,6 +634,7 @@ func TestInferSchema(t *testing.T) {
 			got, err := InferSchema(tt.msg.ProtoReflect().Descriptor())
 			assert.NilError(t, err)
 			assert.DeepEqual(t, tt.expected, got)
+			assert.NilError(t, avro.Validate(got))
 		})
 	}
 }
@@ -583,10 +654,10 @@ func TestInferRootSchema(t *testing.T) {
 				Name:      "Book",
 				Namespace: "google.example.library.v1",
 				Fields: []avro.Field{
-					{Name: "name", Type: avro.Nullable(avro.String())},
-					{Name: "author", Type: avro.Nullable(avro.String())},
-					{Name: "title", Type: avro.Nullable(avro.String())},
-					{Name: "read", Type: avro.Nullable(avro.Boolean())},
+					{Name: "name", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(1)},
+					{Name: "author", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(2)},
+					{Name: "title", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(3)},
+					{Name: "read", Type: avro.Nullable(avro.Boolean()), Default: avro.NullDefault, Props: numberProps(4)},
 				},
 			},
 		},
@@ -605,12 +676,14 @@ func TestInferRootSchema(t *testing.T) {
 							Name:      "Book",
 							Namespace: "google.example.library.v1",
 							Fields: []avro.Field{
-								{Name: "name", Type: avro.Nullable(avro.String())},
-								{Name: "author", Type: avro.Nullable(avro.String())},
-								{Name: "title", Type: avro.Nullable(avro.String())},
-								{Name: "read", Type: avro.Nullable(avro.Boolean())},
+								{Name: "name", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(1)},
+								{Name: "author", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(2)},
+								{Name: "title", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(3)},
+								{Name: "read", Type: avro.Nullable(avro.Boolean()), Default: avro.NullDefault, Props: numberProps(4)},
 							},
 						}),
+						Default: avro.NullDefault,
+						Props:   numberProps(1),
 					},
 					{
 						Name: "update_mask",
@@ -625,9 +698,13 @@ func TestInferRootSchema(t *testing.T) {
 										Type:  avro.ArrayType,
 										Items: avro.Nullable(avro.String()),
 									}),
+									Default: avro.NullDefault,
+									Props:   numberProps(1),
 								},
 							},
 						}),
+						Default: avro.NullDefault,
+						Props:   numberProps(2),
 					},
 				},
 			},
@@ -645,6 +722,8 @@ func TestInferRootSchema(t *testing.T) {
 						Type: avro.Nullable(
 							avro.String(),
 						),
+						Default: avro.NullDefault,
+						Props:   numberProps(1),
 					},
 				},
 			},
@@ -658,8 +737,10 @@ func TestInferRootSchema(t *testing.T) {
 				Namespace: "einride.avro.example.v1",
 				Fields: []avro.Field{
 					{
-						Name: "bytes",
-						Type: avro.Nullable(avro.Bytes()),
+						Name:    "bytes",
+						Type:    avro.Nullable(avro.Bytes()),
+						Default: avro.NullDefault,
+						Props:   numberProps(1),
 					},
 				},
 			},
@@ -672,7 +753,7 @@ func TestInferRootSchema(t *testing.T) {
 				Name:      "ExampleDate",
 				Namespace: "einride.avro.example.v1",
 				Fields: []avro.Field{
-					{Name: "date", Type: avro.Nullable(avro.Date())},
+					{Name: "date", Type: avro.Nullable(avro.Date()), Default: avro.NullDefault, Props: numberProps(1)},
 				},
 			},
 		},
@@ -691,17 +772,19 @@ func TestInferRootSchema(t *testing.T) {
 							Name:      "DateTime",
 							Namespace: "google.type",
 							Fields: []avro.Field{
-								{Name: "year", Type: avro.Nullable(avro.Integer())},
-								{Name: "month", Type: avro.Nullable(avro.Integer())},
-								{Name: "day", Type: avro.Nullable(avro.Integer())},
-								{Name: "hours", Type: avro.Nullable(avro.Integer())},
-								{Name: "minutes", Type: avro.Nullable(avro.Integer())},
-								{Name: "seconds", Type: avro.Nullable(avro.Integer())},
-								{Name: "nanos", Type: avro.Nullable(avro.Integer())},
+								{Name: "year", Type: avro.Nullable(avro.Integer()), Default: avro.NullDefault, Props: numberProps(1)},
+								{Name: "month", Type: avro.Nullable(avro.Integer()), Default: avro.NullDefault, Props: numberProps(2)},
+								{Name: "day", Type: avro.Nullable(avro.Integer()), Default: avro.NullDefault, Props: numberProps(3)},
+								{Name: "hours", Type: avro.Nullable(avro.Integer()), Default: avro.NullDefault, Props: numberProps(4)},
+								{Name: "minutes", Type: avro.Nullable(avro.Integer()), Default: avro.NullDefault, Props: numberProps(5)},
+								{Name: "seconds", Type: avro.Nullable(avro.Integer()), Default: avro.NullDefault, Props: numberProps(6)},
+								{Name: "nanos", Type: avro.Nullable(avro.Integer()), Default: avro.NullDefault, Props: numberProps(7)},
 								{
-									Name: "utc_offset",
-									Doc:  "At most one will be set:\n* utc_offset\n* time_zone",
-									Type: avro.Nullable(avro.Float()),
+									Name:    "utc_offset",
+									Doc:     "At most one will be set:\n* utc_offset\n* time_zone",
+									Type:    avro.Nullable(avro.Float()),
+									Default: avro.NullDefault,
+									Props:   numberProps(8),
 								},
 								{
 									Name: "time_zone",
@@ -711,13 +794,17 @@ func TestInferRootSchema(t *testing.T) {
 										Name:      "TimeZone",
 										Namespace: "google.type",
 										Fields: []avro.Field{
-											{Name: "id", Type: avro.Nullable(avro.String())},
-											{Name: "version", Type: avro.Nullable(avro.String())},
+											{Name: "id", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(1)},
+											{Name: "version", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(2)},
 										},
 									}),
+									Default: avro.NullDefault,
+									Props:   numberProps(9),
 								},
 							},
 						}),
+						Default: avro.NullDefault,
+						Props:   numberProps(1),
 					},
 				},
 			},
@@ -730,7 +817,7 @@ func TestInferRootSchema(t *testing.T) {
 				Name:      "ExampleDuration",
 				Namespace: "einride.avro.example.v1",
 				Fields: []avro.Field{
-					{Name: "duration", Type: avro.Nullable(avro.Float())},
+					{Name: "duration", Type: avro.Nullable(avro.Float()), Default: avro.NullDefault, Props: numberProps(1)},
 				},
 			},
 		},
@@ -754,7 +841,10 @@ func TestInferRootSchema(t *testing.T) {
 								"ENUM_VALUE2",
 								"ENUM_VALUE3",
 							},
+							Default: "ENUM_UNSPECIFIED",
 						}),
+						Default: avro.NullDefault,
+						Props:   numberProps(1),
 					},
 				},
 			},
@@ -773,6 +863,8 @@ func TestInferRootSchema(t *testing.T) {
 							Type:  avro.ArrayType,
 							Items: avro.Nullable(avro.Long()),
 						}),
+						Default: avro.NullDefault,
+						Props:   numberProps(1),
 					},
 					{
 						Name: "string_list",
@@ -780,6 +872,8 @@ func TestInferRootSchema(t *testing.T) {
 							Type:  avro.ArrayType,
 							Items: avro.Nullable(avro.String()),
 						}),
+						Default: avro.NullDefault,
+						Props:   numberProps(2),
 					},
 					{
 						Name: "enum_list",
@@ -794,8 +888,11 @@ func TestInferRootSchema(t *testing.T) {
 									"ENUM_VALUE1",
 									"ENUM_VALUE2",
 								},
+								Default: "ENUM_UNSPECIFIED",
 							}),
 						}),
+						Default: avro.NullDefault,
+						Props:   numberProps(3),
 					},
 					{
 						Name: "nested_list",
@@ -812,10 +909,14 @@ func TestInferRootSchema(t *testing.T) {
 											Type:  avro.ArrayType,
 											Items: avro.Nullable(avro.String()),
 										}),
+										Default: avro.NullDefault,
+										Props:   numberProps(1),
 									},
 								},
 							}),
 						}),
+						Default: avro.NullDefault,
+						Props:   numberProps(4),
 					},
 					{
 						Name: "float_value_list",
@@ -823,6 +924,8 @@ func TestInferRootSchema(t *testing.T) {
 							Type:  avro.ArrayType,
 							Items: avro.Nullable(avro.Float()),
 						}),
+						Default: avro.NullDefault,
+						Props:   numberProps(5),
 					},
 				},
 			},
@@ -844,11 +947,13 @@ func TestInferRootSchema(t *testing.T) {
 								Name:      "StringToStringEntry",
 								Namespace: "einride.avro.example.v1.ExampleMap",
 								Fields: []avro.Field{
-									{Name: "key", Type: avro.Nullable(avro.String())},
-									{Name: "value", Type: avro.Nullable(avro.String())},
+									{Name: "key", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(1)},
+									{Name: "value", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(2)},
 								},
 							},
 						}),
+						Default: avro.NullDefault,
+						Props:   numberProps(1),
 					},
 					{
 						Name: "string_to_nested",
@@ -859,7 +964,7 @@ func TestInferRootSchema(t *testing.T) {
 								Name:      "StringToNestedEntry",
 								Namespace: "einride.avro.example.v1.ExampleMap",
 								Fields: []avro.Field{
-									{Name: "key", Type: avro.Nullable(avro.String())},
+									{Name: "key", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(1)},
 									{
 										Name: "value",
 										Type: avro.Nullable(avro.Record{
@@ -876,18 +981,24 @@ func TestInferRootSchema(t *testing.T) {
 															Name:      "StringToStringEntry",
 															Namespace: "einride.avro.example.v1.ExampleMap.Nested",
 															Fields: []avro.Field{
-																{Name: "key", Type: avro.Nullable(avro.String())},
-																{Name: "value", Type: avro.Nullable(avro.String())},
+																{Name: "key", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(1)},
+																{Name: "value", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(2)},
 															},
 														},
 													}),
+													Default: avro.NullDefault,
+													Props:   numberProps(1),
 												},
 											},
 										}),
+										Default: avro.NullDefault,
+										Props:   numberProps(2),
 									},
 								},
 							},
 						}),
+						Default: avro.NullDefault,
+						Props:   numberProps(2),
 					},
 					{
 						Name: "string_to_enum",
@@ -898,7 +1009,7 @@ func TestInferRootSchema(t *testing.T) {
 								Name:      "StringToEnumEntry",
 								Namespace: "einride.avro.example.v1.ExampleMap",
 								Fields: []avro.Field{
-									{Name: "key", Type: avro.Nullable(avro.String())},
+									{Name: "key", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(1)},
 									{
 										Name: "value",
 										Type: avro.Nullable(avro.Enum{
@@ -910,11 +1021,16 @@ func TestInferRootSchema(t *testing.T) {
 												"ENUM_VALUE1",
 												"ENUM_VALUE2",
 											},
+											Default: "ENUM_UNSPECIFIED",
 										}),
+										Default: avro.NullDefault,
+										Props:   numberProps(2),
 									},
 								},
 							},
 						}),
+						Default: avro.NullDefault,
+						Props:   numberProps(3),
 					},
 					{
 						Name: "int32_to_string",
@@ -925,11 +1041,13 @@ func TestInferRootSchema(t *testing.T) {
 								Name:      "Int32ToStringEntry",
 								Namespace: "einride.avro.example.v1.ExampleMap",
 								Fields: []avro.Field{
-									{Name: "key", Type: avro.Nullable(avro.Integer())},
-									{Name: "value", Type: avro.Nullable(avro.String())},
+									{Name: "key", Type: avro.Nullable(avro.Integer()), Default: avro.NullDefault, Props: numberProps(1)},
+									{Name: "value", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(2)},
 								},
 							},
 						}),
+						Default: avro.NullDefault,
+						Props:   numberProps(4),
 					},
 					{
 						Name: "int64_to_string",
@@ -940,11 +1058,13 @@ func TestInferRootSchema(t *testing.T) {
 								Name:      "Int64ToStringEntry",
 								Namespace: "einride.avro.example.v1.ExampleMap",
 								Fields: []avro.Field{
-									{Name: "key", Type: avro.Nullable(avro.Long())},
-									{Name: "value", Type: avro.Nullable(avro.String())},
+									{Name: "key", Type: avro.Nullable(avro.Long()), Default: avro.NullDefault, Props: numberProps(1)},
+									{Name: "value", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(2)},
 								},
 							},
 						}),
+						Default: avro.NullDefault,
+						Props:   numberProps(5),
 					},
 					{
 						Name: "uint32_to_string",
@@ -955,11 +1075,13 @@ func TestInferRootSchema(t *testing.T) {
 								Name:      "Uint32ToStringEntry",
 								Namespace: "einride.avro.example.v1.ExampleMap",
 								Fields: []avro.Field{
-									{Name: "key", Type: avro.Nullable(avro.Integer())},
-									{Name: "value", Type: avro.Nullable(avro.String())},
+									{Name: "key", Type: avro.Nullable(avro.Integer()), Default: avro.NullDefault, Props: numberProps(1)},
+									{Name: "value", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(2)},
 								},
 							},
 						}),
+						Default: avro.NullDefault,
+						Props:   numberProps(6),
 					},
 					{
 						Name: "bool_to_string",
@@ -970,11 +1092,13 @@ func TestInferRootSchema(t *testing.T) {
 								Name:      "BoolToStringEntry",
 								Namespace: "einride.avro.example.v1.ExampleMap",
 								Fields: []avro.Field{
-									{Name: "key", Type: avro.Nullable(avro.Boolean())},
-									{Name: "value", Type: avro.Nullable(avro.String())},
+									{Name: "key", Type: avro.Nullable(avro.Boolean()), Default: avro.NullDefault, Props: numberProps(1)},
+									{Name: "value", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(2)},
 								},
 							},
 						}),
+						Default: avro.NullDefault,
+						Props:   numberProps(7),
 					},
 					{
 						Name: "string_to_float_value",
@@ -985,11 +1109,13 @@ func TestInferRootSchema(t *testing.T) {
 								Name:      "StringToFloatValueEntry",
 								Namespace: "einride.avro.example.v1.ExampleMap",
 								Fields: []avro.Field{
-									{Name: "key", Type: avro.Nullable(avro.String())},
-									{Name: "value", Type: avro.Nullable(avro.Float())},
+									{Name: "key", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(1)},
+									{Name: "value", Type: avro.Nullable(avro.Float()), Default: avro.NullDefault, Props: numberProps(2)},
 								},
 							},
 						}),
+						Default: avro.NullDefault,
+						Props:   numberProps(8),
 					},
 				},
 			},
@@ -1011,16 +1137,22 @@ func TestInferRootSchema(t *testing.T) {
 							Namespace: "einride.avro.example.v1.ExampleOneof",
 							Fields:    []avro.Field{},
 						}),
+						Default: avro.NullDefault,
+						Props:   numberProps(1),
 					},
 					{
-						Name: "oneof_bool_1",
-						Doc:  "At most one will be set:\n* oneof_empty_message_1\n* oneof_bool_1",
-						Type: avro.Nullable(avro.Boolean()),
+						Name:    "oneof_bool_1",
+						Doc:     "At most one will be set:\n* oneof_empty_message_1\n* oneof_bool_1",
+						Type:    avro.Nullable(avro.Boolean()),
+						Default: avro.NullDefault,
+						Props:   numberProps(2),
 					},
 					{
-						Name: "oneof_empty_message_2",
-						Doc:  "At most one will be set:\n* oneof_empty_message_2\n* oneof_message",
-						Type: avro.Nullable(avro.Reference("einride.avro.example.v1.ExampleOneof.EmptyMessage")),
+						Name:    "oneof_empty_message_2",
+						Doc:     "At most one will be set:\n* oneof_empty_message_2\n* oneof_message",
+						Type:    avro.Nullable(avro.Reference("einride.avro.example.v1.ExampleOneof.EmptyMessage")),
+						Default: avro.NullDefault,
+						Props:   numberProps(3),
 					},
 					{
 						Name: "oneof_message",
@@ -1030,9 +1162,11 @@ func TestInferRootSchema(t *testing.T) {
 							Name:      "Message",
 							Namespace: "einride.avro.example.v1.ExampleOneof",
 							Fields: []avro.Field{
-								{Name: "string_value", Type: avro.Nullable(avro.String())},
+								{Name: "string_value", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(1)},
 							},
 						}),
+						Default: avro.NullDefault,
+						Props:   numberProps(4),
 					},
 				},
 			},
@@ -1050,6 +1184,8 @@ func TestInferRootSchema(t *testing.T) {
 						Type: avro.Nullable(
 							avro.Reference("einride.avro.example.v1.ExampleRecursive"),
 						),
+						Default: avro.NullDefault,
+						Props:   numberProps(1),
 					},
 				},
 			},
@@ -1067,6 +1203,8 @@ func TestInferRootSchema(t *testing.T) {
 						Type: avro.Nullable(
 							avro.String(),
 						),
+						Default: avro.NullDefault,
+						Props:   numberProps(1),
 					},
 				},
 			},
@@ -1079,7 +1217,7 @@ func TestInferRootSchema(t *testing.T) {
 				Name:      "ExampleTimestamp",
 				Namespace: "einride.avro.example.v1",
 				Fields: []avro.Field{
-					{Name: "timestamp", Type: avro.Nullable(avro.TimestampMicros())},
+					{Name: "timestamp", Type: avro.Nullable(avro.TimestampMicros()), Default: avro.NullDefault, Props: numberProps(1)},
 				},
 			},
 		},
@@ -1091,7 +1229,7 @@ func TestInferRootSchema(t *testing.T) {
 				Name:      "ExampleTimeOfDay",
 				Namespace: "einride.avro.example.v1",
 				Fields: []avro.Field{
-					{Name: "time_of_day", Type: avro.Nullable(avro.TimeMicros())},
+					{Name: "time_of_day", Type: avro.Nullable(avro.TimeMicros()), Default: avro.NullDefault, Props: numberProps(1)},
 				},
 			},
 		},
@@ -1103,15 +1241,15 @@ func TestInferRootSchema(t *testing.T) {
 				Name:      "ExampleWrappers",
 				Namespace: "einride.avro.example.v1",
 				Fields: []avro.Field{
-					{Name: "float_value", Type: avro.Nullable(avro.Float())},
-					{Name: "double_value", Type: avro.Nullable(avro.Double())},
-					{Name: "string_value", Type: avro.Nullable(avro.String())},
-					{Name: "bytes_value", Type: avro.Nullable(avro.Bytes())},
-					{Name: "int32_value", Type: avro.Nullable(avro.Integer())},
-					{Name: "int64_value", Type: avro.Nullable(avro.Long())},
-					{Name: "uint32_value", Type: avro.Nullable(avro.Integer())},
-					{Name: "uint64_value", Type: avro.Nullable(avro.Long())},
-					{Name: "bool_value", Type: avro.Nullable(avro.Boolean())},
+					{Name: "float_value", Type: avro.Nullable(avro.Float()), Default: avro.NullDefault, Props: numberProps(1)},
+					{Name: "double_value", Type: avro.Nullable(avro.Double()), Default: avro.NullDefault, Props: numberProps(2)},
+					{Name: "string_value", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(3)},
+					{Name: "bytes_value", Type: avro.Nullable(avro.Bytes()), Default: avro.NullDefault, Props: numberProps(4)},
+					{Name: "int32_value", Type: avro.Nullable(avro.Integer()), Default: avro.NullDefault, Props: numberProps(5)},
+					{Name: "int64_value", Type: avro.Nullable(avro.Long()), Default: avro.NullDefault, Props: numberProps(6)},
+					{Name: "uint32_value", Type: avro.Nullable(avro.Integer()), Default: avro.NullDefault, Props: numberProps(7)},
+					{Name: "uint64_value", Type: avro.Nullable(avro.Long()), Default: avro.NullDefault, Props: numberProps(8)},
+					{Name: "bool_value", Type: avro.Nullable(avro.Boolean()), Default: avro.NullDefault, Props: numberProps(9)},
 				},
 			},
 		},
@@ -1122,6 +1260,7 @@ func TestInferRootSchema(t *testing.T) {
 			got, err := SchemaOptions{OmitRootElement: true}.InferSchema(tt.msg.ProtoReflect().Descriptor())
 			assert.NilError(t, err)
 			assert.DeepEqual(t, tt.expected, got)
+			assert.NilError(t, avro.Validate(got))
 		})
 	}
 }
@@ -1147,6 +1286,8 @@ func TestInferSchemaOptions(t *testing.T) {
 							Type:  avro.ArrayType,
 							Items: avro.Long(),
 						},
+						Default: []interface{}{},
+						Props:   numberProps(1),
 					},
 					{
 						Name: "string_list",
@@ -1154,6 +1295,8 @@ func TestInferSchemaOptions(t *testing.T) {
 							Type:  avro.ArrayType,
 							Items: avro.String(),
 						},
+						Default: []interface{}{},
+						Props:   numberProps(2),
 					},
 					{
 						Name: "enum_list",
@@ -1168,8 +1311,11 @@ func TestInferSchemaOptions(t *testing.T) {
 									"ENUM_VALUE1",
 									"ENUM_VALUE2",
 								},
+								Default: "ENUM_UNSPECIFIED",
 							},
 						},
+						Default: []interface{}{},
+						Props:   numberProps(3),
 					},
 					{
 						Name: "nested_list",
@@ -1186,10 +1332,14 @@ func TestInferSchemaOptions(t *testing.T) {
 											Type:  avro.ArrayType,
 											Items: avro.String(),
 										},
+										Default: []interface{}{},
+										Props:   numberProps(1),
 									},
 								},
 							},
 						},
+						Default: []interface{}{},
+						Props:   numberProps(4),
 					},
 					{
 						Name: "float_value_list",
@@ -1197,6 +1347,8 @@ func TestInferSchemaOptions(t *testing.T) {
 							Type:  avro.ArrayType,
 							Items: avro.Float(),
 						},
+						Default: []interface{}{},
+						Props:   numberProps(5),
 					},
 				},
 			}),
@@ -1210,6 +1362,126 @@ func TestInferSchemaOptions(t *testing.T) {
 			got, err := tt.opt.InferSchema(tt.msg.ProtoReflect().Descriptor())
 			assert.NilError(t, err)
 			assert.DeepEqual(t, tt.expected, got)
+			assert.NilError(t, avro.Validate(got))
+		})
+	}
+}
+
+func TestInferSchemaDefaults(t *testing.T) {
+	t.Parallel()
+	opts := SchemaOptions{
+		OmitRootElement: true,
+		DefaultCallback: func(field protoreflect.FieldDescriptor) (interface{}, bool) {
+			if field.Name() == "title" {
+				// no default value
+				return nil, true
+			}
+			return nil, false
+		},
+	}
+	schema, err := opts.InferSchema((&library.Book{}).ProtoReflect().Descriptor())
+	assert.NilError(t, err)
+	schemaBytes, err := json.Marshal(schema)
+	assert.NilError(t, err)
+	assert.Equal(
+		t,
+		`{"type":"record","namespace":"google.example.library.v1","name":"Book","fields":[`+
+			`{"name":"name","type":[{"type":"null"},{"type":"string"}],"default":null,"proto.number":1},`+
+			`{"name":"author","type":[{"type":"null"},{"type":"string"}],"default":null,"proto.number":2},`+
+			`{"name":"title","type":[{"type":"null"},{"type":"string"}],"proto.number":3},`+
+			`{"name":"read","type":[{"type":"null"},{"type":"boolean"}],"default":null,"proto.number":4}]}`,
+		string(schemaBytes),
+	)
+}
+
+func TestDefaultValue(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		name     string
+		schema   avro.Schema
+		expected interface{}
+	}{
+		{name: "nullable", schema: avro.Nullable(avro.String()), expected: avro.NullDefault},
+		{name: "boolean", schema: avro.Boolean(), expected: false},
+		{name: "long", schema: avro.Long(), expected: 0},
+		{name: "double", schema: avro.Double(), expected: 0},
+		{name: "string", schema: avro.String(), expected: ""},
+		{name: "bytes", schema: avro.Bytes(), expected: ""},
+		{name: "timestamp", schema: avro.TimestampMicros(), expected: 0},
+		{
+			name:     "enum",
+			schema:   avro.Enum{Type: avro.EnumType, Name: "Enum", Symbols: []string{"A", "B"}, Default: "A"},
+			expected: "A",
+		},
+		{
+			name:     "array",
+			schema:   avro.Array{Type: avro.ArrayType, Items: avro.Nullable(avro.String())},
+			expected: []interface{}{},
+		},
+		{
+			name:     "fixed",
+			schema:   avro.Fixed{Type: avro.FixedType, Name: "id", Size: 2},
+			expected: "\u0000\u0000",
+		},
+		{
+			name:     "record",
+			schema:   avro.Record{Type: avro.RecordType, Name: "Record"},
+			expected: nil,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.DeepEqual(t, tt.expected, defaultValue(tt.schema))
 		})
 	}
 }
+
+func TestInferSchemaAliases(t *testing.T) {
+	t.Parallel()
+	opts := SchemaOptions{
+		OmitRootElement: true,
+		AliasesCallback: Aliases(map[protoreflect.FullName][]string{
+			"google.example.library.v1.Book":       {"Volume"},
+			"google.example.library.v1.Book.title": {"heading"},
+		}),
+	}
+	schema, err := opts.InferSchema((&library.Book{}).ProtoReflect().Descriptor())
+	assert.NilError(t, err)
+	assert.DeepEqual(t, avro.Record{
+		Type:      avro.RecordType,
+		Name:      "Book",
+		Namespace: "google.example.library.v1",
+		Aliases:   []string{"Volume"},
+		Fields: []avro.Field{
+			{Name: "name", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(1)},
+			{Name: "author", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(2)},
+			{Name: "title", Aliases: []string{"heading"}, Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(3)},
+			{Name: "read", Type: avro.Nullable(avro.Boolean()), Default: avro.NullDefault, Props: numberProps(4)},
+		},
+	}, schema)
+
+	t.Run("decode", func(t *testing.T) {
+		t.Parallel()
+		// record written before the message and its fields were renamed
+		data := map[string]interface{}{
+			"google.example.library.v1.Volume": map[string]interface{}{
+				"name":    map[string]interface{}{"string": "shelves/1/books/1"},
+				"heading": map[string]interface{}{"string": "Harry Potter"},
+				"author":  map[string]interface{}{"string": "J. K. Rowling"},
+			},
+		}
+		var book library.Book
+		assert.NilError(t, opts.decodeJSON(data, &book))
+		assert.DeepEqual(t, &library.Book{
+			Name:   "shelves/1/books/1",
+			Author: "J. K. Rowling",
+			Title:  "Harry Potter",
+		}, &book, protocmp.Transform())
+	})
+}
+
+// numberProps returns the props of fields with the field number.
+func numberProps(number int) map[string]interface{} {
+	return map[string]interface{}{fieldNumberProp: number}
+}
@@ -0,0 +1,48 @@
+package protoavro
+
+import (
+	"fmt"
+
+	"go.einride.tech/protobuf-avro/avro"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// unknownFieldName is the name of the field of the unknown fields of a message,
+// when they are preserved.
+const unknownFieldName = "_unknown"
+
+// preserveUnknown reports whether the record of the message has a field of its unknown fields.
+// Unknown fields can not be selected by field masks, and are dropped from map entries by the
+// protobuf runtime.
+func (o SchemaOptions) preserveUnknown(desc protoreflect.MessageDescriptor, mask fieldMask) bool {
+	return o.PreserveUnknownFields && mask == nil && !desc.IsMapEntry()
+}
+
+func schemaUnknown() avro.Field {
+	return avro.Field{
+		Name:    unknownFieldName,
+		Doc:     "Unknown fields of the message, in protobuf wire format.",
+		Type:    avro.Nullable(avro.Bytes()),
+		Default: avro.NullDefault,
+	}
+}
+
+func (o SchemaOptions) encodeUnknown(message protoreflect.Message) interface{} {
+	raw := message.GetUnknown()
+	if len(raw) == 0 {
+		return nil
+	}
+	return o.unionValue("bytes", []byte(raw))
+}
+
+func (o SchemaOptions) decodeUnknown(data interface{}, message protoreflect.Message) error {
+	if data == nil || !o.PreserveUnknownFields {
+		return nil
+	}
+	raw, err := decodeBytesLike(data, "bytes")
+	if err != nil {
+		return fmt.Errorf("unknown fields: %w", err)
+	}
+	message.SetUnknown(append(message.GetUnknown(), raw...))
+	return nil
+}
@@ -0,0 +1,67 @@
+package protoavro
+
+import (
+	"bytes"
+	"testing"
+
+	"go.einride.tech/protobuf-avro/avro"
+	"google.golang.org/genproto/googleapis/example/library/v1"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/testing/protocmp"
+	"gotest.tools/v3/assert"
+)
+
+func Test_PreserveUnknownFields(t *testing.T) {
+	opts := SchemaOptions{OmitRootElement: true, PreserveUnknownFields: true}
+	// a field added to Book after the generated code
+	unknown := protowire.AppendTag(nil, 5, protowire.BytesType)
+	unknown = protowire.AppendString(unknown, "Bloomsbury")
+	newBook := func() *library.Book {
+		book := &library.Book{Name: "shelves/1/books/1", Title: "Harry Potter"}
+		book.ProtoReflect().SetUnknown(unknown)
+		return book
+	}
+
+	t.Run("schema", func(t *testing.T) {
+		schema, err := opts.InferSchema((&library.Book{}).ProtoReflect().Descriptor())
+		assert.NilError(t, err)
+		fields := schema.(avro.Record).Fields
+		assert.DeepEqual(t, schemaUnknown(), fields[len(fields)-1])
+	})
+
+	t.Run("roundtrip", func(t *testing.T) {
+		msg := &library.CreateBookRequest{Parent: "shelves/1", Book: newBook()}
+		msg.ProtoReflect().SetUnknown(unknown)
+		var b bytes.Buffer
+		marshaler, err := opts.NewMarshaler(msg.ProtoReflect().Descriptor(), &b)
+		assert.NilError(t, err)
+		assert.NilError(t, marshaler.Marshal(msg))
+		assert.NilError(t, marshaler.Marshal(&library.CreateBookRequest{Parent: "shelves/2"}))
+		unmarshaler, err := opts.NewUnmarshaler(&b)
+		assert.NilError(t, err)
+		assert.Assert(t, unmarshaler.Scan())
+		got := &library.CreateBookRequest{}
+		assert.NilError(t, unmarshaler.Unmarshal(got))
+		assert.DeepEqual(t, msg, got, protocmp.Transform())
+		assert.DeepEqual(t, []byte(unknown), []byte(got.GetBook().ProtoReflect().GetUnknown()))
+		assert.Assert(t, unmarshaler.Scan())
+		got = &library.CreateBookRequest{}
+		assert.NilError(t, unmarshaler.Unmarshal(got))
+		assert.Equal(t, 0, len(got.ProtoReflect().GetUnknown()))
+	})
+
+	t.Run("dropped when not preserved", func(t *testing.T) {
+		msg := newBook()
+		var b bytes.Buffer
+		marshaler, err := opts.NewMarshaler(msg.ProtoReflect().Descriptor(), &b)
+		assert.NilError(t, err)
+		assert.NilError(t, marshaler.Marshal(msg))
+		unmarshaler, err := SchemaOptions{OmitRootElement: true}.NewUnmarshaler(&b)
+		assert.NilError(t, err)
+		assert.Assert(t, unmarshaler.Scan())
+		got := &library.Book{}
+		assert.NilError(t, unmarshaler.Unmarshal(got))
+		assert.DeepEqual(t, &library.Book{Name: "shelves/1/books/1", Title: "Harry Potter"}, got, protocmp.Transform())
+	})
+
+}
@@ -11,11 +11,7 @@ import (
 // NewUnmarshaler returns a new unmarshaler that reads protobuf messages from reader in
 // Avro binary format.
 func NewUnmarshaler(reader io.Reader) (*Unmarshaler, error) {
-	r, err := goavro.NewOCFReader(reader)
-	if err != nil {
-		return nil, fmt.Errorf("new ocf writer: %w", err)
-	}
-	return &Unmarshaler{r: r}, nil
+	return SchemaOptions{}.NewUnmarshaler(reader)
 }
 
 // NewUnmarshaler returns a new unmarshaler that reads protobuf messages from reader in
@@ -25,6 +21,11 @@ func (o SchemaOptions) NewUnmarshaler(reader io.Reader) (*Unmarshaler, error) {
 	if err != nil {
 		return nil, fmt.Errorf("new ocf writer: %w", err)
 	}
+	fields, err := writerFields(r.Codec().Schema())
+	if err != nil {
+		return nil, fmt.Errorf("field IDs: %w", err)
+	}
+	o.writerFields = fields
 	return &Unmarshaler{opts: o, r: r}, nil
 }
 
@@ -0,0 +1,65 @@
+package protoavro
+
+import (
+	"encoding/hex"
+	"fmt"
+	"regexp"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// nilUUID is the uuid with all bits set to zero.
+const nilUUID = "00000000-0000-0000-0000-000000000000"
+
+var uuidRegexp = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+func (o SchemaOptions) uuidJSON(field protoreflect.FieldDescriptor, value string, useUnion bool) (interface{}, error) {
+	if value == "" {
+		if useUnion {
+			// unset uuids are encoded as null
+			return nil, nil
+		}
+		// unset uuids that are not nullable are encoded as the nil uuid
+		value = nilUUID
+	}
+	if !uuidRegexp.MatchString(value) {
+		return nil, fmt.Errorf("field %s: invalid uuid '%s'", field.Name(), value)
+	}
+	// goavro has no codec for the uuid logical type, and uses the underlying string type
+	return o.maybeUnionValue("string", value, useUnion), nil
+}
+
+// decodeUUID decodes a uuid encoded either as a string, or as a fixed of 16 bytes.
+// Null and the nil uuid, which unset uuids are encoded as, are decoded as unset.
+func decodeUUID(v interface{}) (string, error) {
+	u, err := decodeUUIDLike(v)
+	if err != nil || u == nilUUID {
+		return "", err
+	}
+	return u, nil
+}
+
+func decodeUUIDLike(v interface{}) (string, error) {
+	if m, ok := v.(map[string]interface{}); ok && len(m) == 1 {
+		for _, value := range m {
+			v = value
+		}
+	}
+	switch u := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		if !uuidRegexp.MatchString(u) {
+			return "", fmt.Errorf("invalid uuid '%s'", u)
+		}
+		return u, nil
+	case []byte:
+		if len(u) != 16 {
+			return "", fmt.Errorf("expected uuid of 16 bytes, got %d", len(u))
+		}
+		h := hex.EncodeToString(u)
+		return fmt.Sprintf("%s-%s-%s-%s-%s", h[0:8], h[8:12], h[12:16], h[16:20], h[20:]), nil
+	default:
+		return "", fmt.Errorf("expected uuid-like, got %v", v)
+	}
+}
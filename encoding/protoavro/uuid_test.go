@@ -0,0 +1,192 @@
+package protoavro
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/linkedin/goavro/v2"
+	"go.einride.tech/protobuf-avro/avro"
+	examplev1 "go.einride.tech/protobuf-avro/internal/examples/proto/gen/einride/avro/example/v1"
+	"google.golang.org/genproto/googleapis/example/library/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+	"gotest.tools/v3/assert"
+)
+
+func Test_UUID(t *testing.T) {
+	opts := SchemaOptions{
+		OmitRootElement: true,
+		UUIDCallback:    UUIDFields("google.example.library.v1.Book.name"),
+	}
+	msg := &library.Book{
+		Name:  "3f2504e0-4f89-11d3-9a0c-0305e82c3301",
+		Title: "Harry Potter",
+	}
+
+	schema, err := opts.InferSchema(msg.ProtoReflect().Descriptor())
+	assert.NilError(t, err)
+	assert.DeepEqual(t, avro.Record{
+		Type:      avro.RecordType,
+		Name:      "Book",
+		Namespace: "google.example.library.v1",
+		Fields: []avro.Field{
+			{Name: "name", Type: avro.Nullable(avro.UUID()), Default: avro.NullDefault, Props: numberProps(1)},
+			{Name: "author", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(2)},
+			{Name: "title", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(3)},
+			{Name: "read", Type: avro.Nullable(avro.Boolean()), Default: avro.NullDefault, Props: numberProps(4)},
+		},
+	}, schema)
+
+	got, err := opts.encodeJSON(msg)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, map[string]interface{}{"string": "3f2504e0-4f89-11d3-9a0c-0305e82c3301"}, got.(map[string]interface{})["name"])
+
+	// assert that it round trips through goavro
+	schemaBytes, err := json.Marshal(schema)
+	assert.NilError(t, err)
+	codec, err := goavro.NewCodec(string(schemaBytes))
+	assert.NilError(t, err)
+	binary, err := codec.BinaryFromNative(nil, got)
+	assert.NilError(t, err)
+	native, _, err := codec.NativeFromBinary(binary)
+	assert.NilError(t, err)
+	var decoded library.Book
+	assert.NilError(t, opts.decodeJSON(native, &decoded))
+	assert.DeepEqual(t, msg, &decoded, protocmp.Transform())
+
+	_, err = opts.encodeJSON(&library.Book{Name: "shelves/1/books/1"})
+	assert.ErrorContains(t, err, "field name: invalid uuid 'shelves/1/books/1'")
+}
+
+func Test_UUID_Empty(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		opts     SchemaOptions
+		msg      proto.Message
+		expected interface{}
+		field    string
+	}{
+		{
+			name:  "nullable field",
+			opts:  SchemaOptions{UUIDCallback: UUIDFields("google.example.library.v1.Book.name")},
+			msg:   &library.Book{Title: "Harry Potter"},
+			field: "name",
+		},
+		{
+			name: "required field",
+			opts: SchemaOptions{UUIDCallback: UUIDFields("einride.avro.example.v1.ExampleProto2.required_string")},
+			msg: &examplev1.ExampleProto2{
+				RequiredString:  proto.String(""),
+				RequiredMessage: &examplev1.ExampleProto2_Nested{RequiredInt64: proto.Int64(1)},
+			},
+			field:    "required_string",
+			expected: nilUUID,
+		},
+		{
+			name:     "nullable array",
+			opts:     SchemaOptions{UUIDCallback: UUIDFields("einride.avro.example.v1.ExampleList.string_list")},
+			msg:      &examplev1.ExampleList{StringList: []string{""}},
+			field:    "string_list",
+			expected: map[string]interface{}{"array": []interface{}{nil}},
+		},
+		{
+			name: "array without nulls",
+			opts: SchemaOptions{
+				OmitNullArray: true,
+				UUIDCallback:  UUIDFields("einride.avro.example.v1.ExampleList.string_list"),
+			},
+			msg:      &examplev1.ExampleList{StringList: []string{""}},
+			field:    "string_list",
+			expected: []interface{}{nilUUID},
+		},
+		{
+			name: "map value",
+			opts: SchemaOptions{
+				UUIDCallback: UUIDFields("einride.avro.example.v1.ExampleMap.StringToStringEntry.value"),
+			},
+			msg:   &examplev1.ExampleMap{StringToString: map[string]string{"key": ""}},
+			field: "string_to_string",
+			expected: map[string]interface{}{
+				"array": []interface{}{map[string]interface{}{"key": map[string]interface{}{"string": "key"}, "value": nil}},
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			tt.opts.OmitRootElement = true
+			schema, err := tt.opts.InferSchema(tt.msg.ProtoReflect().Descriptor())
+			assert.NilError(t, err)
+			got, err := tt.opts.encodeJSON(tt.msg)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tt.expected, got.(map[string]interface{})[tt.field])
+			// assert that empty uuids round trip through goavro
+			schemaBytes, err := json.Marshal(schema)
+			assert.NilError(t, err)
+			codec, err := goavro.NewCodec(string(schemaBytes))
+			assert.NilError(t, err)
+			binary, err := codec.BinaryFromNative(nil, got)
+			assert.NilError(t, err)
+			native, _, err := codec.NativeFromBinary(binary)
+			assert.NilError(t, err)
+			decoded := tt.msg.ProtoReflect().New().Interface()
+			assert.NilError(t, tt.opts.decodeJSON(native, decoded))
+			assert.DeepEqual(t, tt.msg, decoded, protocmp.Transform())
+		})
+	}
+}
+
+func Test_DecodeUUID(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		data        interface{}
+		expected    string
+		errContains string
+	}{
+		{
+			name:     "string",
+			data:     map[string]interface{}{"string": "3f2504e0-4f89-11d3-9a0c-0305e82c3301"},
+			expected: "3f2504e0-4f89-11d3-9a0c-0305e82c3301",
+		},
+		{
+			name: "fixed",
+			data: map[string]interface{}{
+				"example.uuid": []byte{
+					0x3f, 0x25, 0x04, 0xe0, 0x4f, 0x89, 0x11, 0xd3,
+					0x9a, 0x0c, 0x03, 0x05, 0xe8, 0x2c, 0x33, 0x01,
+				},
+			},
+			expected: "3f2504e0-4f89-11d3-9a0c-0305e82c3301",
+		},
+		{
+			name:     "null",
+			data:     nil,
+			expected: "",
+		},
+		{
+			name:     "nil uuid",
+			data:     nilUUID,
+			expected: "",
+		},
+		{
+			name:        "invalid string",
+			data:        map[string]interface{}{"string": "not a uuid"},
+			errContains: "invalid uuid 'not a uuid'",
+		},
+		{
+			name:        "invalid fixed",
+			data:        map[string]interface{}{"example.uuid": []byte{1, 2, 3}},
+			errContains: "expected uuid of 16 bytes, got 3",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeUUID(tt.data)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"go.einride.tech/protobuf-avro/avro"
 	"go.einride.tech/protobuf-avro/internal/wkt"
 	"google.golang.org/genproto/googleapis/type/date"
+	"google.golang.org/genproto/googleapis/type/datetime"
 	"google.golang.org/genproto/googleapis/type/timeofday"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
@@ -42,7 +43,33 @@ func isWKT(name protoreflect.FullName) bool {
 	return false
 }
 
-func schemaWKT(message protoreflect.MessageDescriptor) (avro.Schema, error) {
+func isWrapper(name protoreflect.FullName) bool {
+	switch name {
+	case wkt.DoubleValue,
+		wkt.FloatValue,
+		wkt.Int32Value,
+		wkt.UInt32Value,
+		wkt.Int64Value,
+		wkt.UInt64Value,
+		wkt.BoolValue,
+		wkt.StringValue,
+		wkt.BytesValue:
+		return true
+	}
+	return false
+}
+
+// unwrapUnion returns the value of a union with a single key.
+func unwrapUnion(value interface{}) interface{} {
+	if union, ok := value.(map[string]interface{}); ok && len(union) == 1 {
+		for _, v := range union {
+			return v
+		}
+	}
+	return value
+}
+
+func (s schemaInferrer) schemaWKT(message protoreflect.MessageDescriptor, recursiveIndex int) (avro.Schema, error) {
 	switch message.FullName() {
 	case wkt.DoubleValue,
 		wkt.FloatValue,
@@ -59,11 +86,11 @@ func schemaWKT(message protoreflect.MessageDescriptor) (avro.Schema, error) {
 		}
 		return schema, nil
 	case wkt.Struct:
-		return schemaStruct(), nil
+		return s.schemaStruct(), nil
 	case wkt.Value:
-		return schemaValue(), nil
+		return s.schemaValue(), nil
 	case wkt.Any:
-		return schemaAny(), nil
+		return s.schemaAny(recursiveIndex)
 	case wkt.Timestamp:
 		return schemaTimestamp(), nil
 	case wkt.Duration:
@@ -72,12 +99,22 @@ func schemaWKT(message protoreflect.MessageDescriptor) (avro.Schema, error) {
 		return schemaDate(), nil
 	case wkt.TimeOfDay:
 		return schemaTimeOfDay(), nil
+	case wkt.DateTime:
+		return schemaDateTime(), nil
 	}
 	return nil, fmt.Errorf("uknown wellknown type %s", message.FullName())
 }
 
 func (o SchemaOptions) encodeWKT(message protoreflect.Message, useUnion bool) (interface{}, error) {
 	desc := message.Descriptor()
+	if !useUnion && !isWrapper(desc.FullName()) {
+		// elements of arrays that are not nullable are encoded without the union
+		value, err := o.encodeWKT(message, true)
+		if err != nil {
+			return nil, err
+		}
+		return unwrapUnion(value), nil
+	}
 	switch desc.FullName() {
 	case wkt.DoubleValue,
 		wkt.FloatValue,
@@ -94,55 +131,138 @@ func (o SchemaOptions) encodeWKT(message protoreflect.Message, useUnion bool) (i
 		}
 		return value, nil
 	case wkt.Struct:
-		value, err := o.encodeStruct(message.Interface().(*structpb.Struct))
+		v, err := asWKT[*structpb.Struct](message)
 		if err != nil {
 			return nil, err
 		}
-		return value, nil
+		return o.encodeStruct(v)
 	case wkt.Value:
-		value, err := o.encodeValue(message.Interface().(*structpb.Value))
+		v, err := asWKT[*structpb.Value](message)
 		if err != nil {
 			return nil, err
 		}
-		return value, nil
+		return o.encodeValue(v)
 	case wkt.Any:
-		value, err := o.encodeAny(message.Interface().(*anypb.Any))
+		v, err := asWKT[*anypb.Any](message)
 		if err != nil {
 			return nil, err
 		}
-		return value, nil
+		return o.encodeAny(v)
 	case wkt.Timestamp:
-		return o.encodeTimestamp(message.Interface().(*timestamppb.Timestamp)), nil
+		v, err := asWKT[*timestamppb.Timestamp](message)
+		if err != nil {
+			return nil, err
+		}
+		return o.encodeTimestamp(v), nil
 	case wkt.Duration:
-		return o.encodeDuration(message.Interface().(*durationpb.Duration)), nil
+		v, err := asWKT[*durationpb.Duration](message)
+		if err != nil {
+			return nil, err
+		}
+		return o.encodeDuration(v), nil
 	case wkt.Date:
-		return o.encodeDate(message.Interface().(*date.Date)), nil
+		v, err := asWKT[*date.Date](message)
+		if err != nil {
+			return nil, err
+		}
+		return o.encodeDate(v), nil
 	case wkt.TimeOfDay:
-		return o.encodeTimeOfDay(message.Interface().(*timeofday.TimeOfDay)), nil
+		v, err := asWKT[*timeofday.TimeOfDay](message)
+		if err != nil {
+			return nil, err
+		}
+		return o.encodeTimeOfDay(v), nil
+	case wkt.DateTime:
+		v, err := asWKT[*datetime.DateTime](message)
+		if err != nil {
+			return nil, err
+		}
+		return o.encodeDateTime(v)
 	default:
 		return nil, fmt.Errorf("unknown wellknown type %s", desc.FullName())
 	}
 }
 
-func decodeWKT(data map[string]interface{}, msg protoreflect.Message) error {
+// wrapWKT returns the value of a well known type as a union, for elements of arrays
+// that are not nullable and therefore not encoded as unions.
+func (o *SchemaOptions) wrapWKT(data interface{}, desc protoreflect.MessageDescriptor) map[string]interface{} {
+	branch := o.wktBranch(desc.FullName())
+	if union, ok := data.(map[string]interface{}); ok {
+		if _, ok := union[branch]; ok || branch == "" {
+			return union
+		}
+	}
+	return map[string]interface{}{branch: data}
+}
+
+// wktBranch returns the name of the non-null union branch of a well known type,
+// or an empty string when there are several.
+func (o *SchemaOptions) wktBranch(name protoreflect.FullName) string {
+	switch name {
+	case wkt.DoubleValue:
+		return "double"
+	case wkt.FloatValue, wkt.Duration:
+		return "float"
+	case wkt.Int32Value, wkt.UInt32Value:
+		return "int"
+	case wkt.Int64Value, wkt.UInt64Value:
+		return "long"
+	case wkt.BoolValue:
+		return "boolean"
+	case wkt.StringValue, wkt.DateTime:
+		return "string"
+	case wkt.BytesValue:
+		return "bytes"
+	case wkt.Timestamp:
+		return "long.timestamp-micros"
+	case wkt.Date:
+		return "int.date"
+	case wkt.TimeOfDay:
+		return "long.time-micros"
+	case wkt.Struct:
+		if o.structuredStruct() {
+			return "map"
+		}
+		return "string"
+	case wkt.Value:
+		if o.structuredStruct() {
+			return wkt.Value
+		}
+		return "string"
+	case wkt.Any:
+		switch {
+		case !o.StructuredWKT:
+			return "string"
+		case o.AnyTypes == nil:
+			return wkt.Any
+		case o.flattenUnions():
+			return anyTypesName
+		}
+	}
+	return ""
+}
+
+func (o *SchemaOptions) decodeWKT(data map[string]interface{}, msg protoreflect.Message) error {
 	desc := msg.Descriptor()
 	var value proto.Message
 	var err error
 	switch desc.FullName() {
 	case wkt.Any:
-		value, err = decodeAny(data)
+		value, err = o.decodeAny(data)
 	case wkt.Date:
 		value, err = decodeDate(data)
 	case wkt.Struct:
-		value, err = decodeStruct(data)
+		value, err = o.decodeStruct(data)
 	case wkt.Value:
-		value, err = decodeValue(data)
+		value, err = o.decodeValue(data)
 	case wkt.TimeOfDay:
 		value, err = decodeTimeOfDay(data)
 	case wkt.Duration:
 		value, err = decodeDuration(data)
 	case wkt.Timestamp:
 		value, err = decodeTimestamp(data)
+	case wkt.DateTime:
+		value, err = decodeDateTime(data)
 	case wkt.FloatValue,
 		wkt.DoubleValue,
 		wkt.UInt32Value,
@@ -159,7 +279,41 @@ func decodeWKT(data map[string]interface{}, msg protoreflect.Message) error {
 	if err != nil {
 		return err
 	}
-	proto.Merge(msg.Interface(), value)
+	return mergeWKT(msg, value)
+}
+
+// asWKT returns the well known type message as its generated Go type.
+// Messages of other types, such as dynamic messages, are converted through the wire format.
+func asWKT[T proto.Message](message protoreflect.Message) (T, error) {
+	if m, ok := message.Interface().(T); ok {
+		return m, nil
+	}
+	var zero T
+	m := zero.ProtoReflect().New().Interface().(T)
+	data, err := proto.Marshal(message.Interface())
+	if err != nil {
+		return zero, fmt.Errorf("%s: marshal: %w", message.Descriptor().FullName(), err)
+	}
+	if err := proto.Unmarshal(data, m); err != nil {
+		return zero, fmt.Errorf("%s: unmarshal: %w", message.Descriptor().FullName(), err)
+	}
+	return m, nil
+}
+
+// mergeWKT merges the decoded well known type value into msg, which may be
+// a message of another type with the same descriptor, such as a dynamic message.
+func mergeWKT(msg protoreflect.Message, value proto.Message) error {
+	if msg.Type() == value.ProtoReflect().Type() {
+		proto.Merge(msg.Interface(), value)
+		return nil
+	}
+	data, err := proto.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("%s: marshal: %w", msg.Descriptor().FullName(), err)
+	}
+	if err := (proto.UnmarshalOptions{Merge: true}).Unmarshal(data, msg.Interface()); err != nil {
+		return fmt.Errorf("%s: unmarshal: %w", msg.Descriptor().FullName(), err)
+	}
 	return nil
 }
 
@@ -188,25 +342,27 @@ func (o SchemaOptions) encodeWrapper(msg protoreflect.Message, useUnion bool) (i
 	if msg == nil {
 		return nil, nil
 	}
+	// wrappers are read through reflection to support dynamic messages
+	value := msg.Get(msg.Descriptor().Fields().ByName("value"))
 	switch msg.Descriptor().FullName() {
 	case wkt.DoubleValue:
-		return o.maybeUnionValue("double", msg.Interface().(*wrapperspb.DoubleValue).GetValue(), useUnion), nil
+		return o.maybeUnionValue("double", value.Float(), useUnion), nil
 	case wkt.FloatValue:
-		return o.maybeUnionValue("float", msg.Interface().(*wrapperspb.FloatValue).GetValue(), useUnion), nil
+		return o.maybeUnionValue("float", float32(value.Float()), useUnion), nil
 	case wkt.Int32Value:
-		return o.maybeUnionValue("int", msg.Interface().(*wrapperspb.Int32Value).GetValue(), useUnion), nil
+		return o.maybeUnionValue("int", int32(value.Int()), useUnion), nil
 	case wkt.UInt32Value:
-		return o.maybeUnionValue("int", int32(msg.Interface().(*wrapperspb.UInt32Value).GetValue()), useUnion), nil
+		return o.maybeUnionValue("int", int32(value.Uint()), useUnion), nil
 	case wkt.Int64Value:
-		return o.maybeUnionValue("long", msg.Interface().(*wrapperspb.Int64Value).GetValue(), useUnion), nil
+		return o.maybeUnionValue("long", value.Int(), useUnion), nil
 	case wkt.UInt64Value:
-		return o.maybeUnionValue("long", int64(msg.Interface().(*wrapperspb.UInt64Value).GetValue()), useUnion), nil
+		return o.maybeUnionValue("long", int64(value.Uint()), useUnion), nil
 	case wkt.BoolValue:
-		return o.maybeUnionValue("boolean", msg.Interface().(*wrapperspb.BoolValue).GetValue(), useUnion), nil
+		return o.maybeUnionValue("boolean", value.Bool(), useUnion), nil
 	case wkt.StringValue:
-		return o.maybeUnionValue("string", msg.Interface().(*wrapperspb.StringValue).GetValue(), useUnion), nil
+		return o.maybeUnionValue("string", value.String(), useUnion), nil
 	case wkt.BytesValue:
-		return o.maybeUnionValue("bytes", msg.Interface().(*wrapperspb.BytesValue).GetValue(), useUnion), nil
+		return o.maybeUnionValue("bytes", value.Bytes(), useUnion), nil
 	default:
 		return nil, fmt.Errorf("unknown wrapper type %s", msg.Descriptor().FullName())
 	}
@@ -318,39 +474,54 @@ func dateFromCivil(c civil.Date) *date.Date {
 	}
 }
 
-func schemaAny() avro.Schema {
-	return avro.Nullable(avro.String()) // EncodeJSON string
+func (s schemaInferrer) schemaAny(recursiveIndex int) (avro.Schema, error) {
+	if s.opts.StructuredWKT {
+		return s.structuredAnySchema(recursiveIndex)
+	}
+	return avro.Nullable(avro.String()), nil // EncodeJSON string
 }
 
-func (o SchemaOptions) encodeAny(a *anypb.Any) (map[string]interface{}, error) {
-	data, err := protojson.Marshal(a)
+func (o SchemaOptions) encodeAny(a *anypb.Any) (interface{}, error) {
+	if o.StructuredWKT {
+		return o.encodeStructuredAny(a)
+	}
+	data, err := o.marshalAnyJSON(a)
 	if err != nil {
 		return nil, fmt.Errorf("google.protobuf.Any: marshal: %w", err)
 	}
 	return o.unionValue("string", string(data)), nil
 }
 
-func decodeAny(v map[string]interface{}) (*anypb.Any, error) {
+func (o *SchemaOptions) decodeAny(v map[string]interface{}) (*anypb.Any, error) {
 	if v == nil {
 		return nil, nil
 	}
+	if o.StructuredWKT {
+		return o.decodeStructuredAny(v)
+	}
 	str, err := decodeString(v, "string")
 	if err != nil {
 		return nil, fmt.Errorf("google.protobuf.Any: %w", err)
 	}
-	var value anypb.Any
-	if err := protojson.Unmarshal([]byte(str), &value); err != nil {
-		return nil, fmt.Errorf("google.protobuf.Any: unmarshal: %w", err)
+	value, err := o.unmarshalAnyJSON([]byte(str))
+	if err != nil {
+		return nil, fmt.Errorf("google.protobuf.Any: %w", err)
 	}
-	return &value, nil
+	return value, nil
 }
 
 // schema value
-func schemaValue() avro.Schema {
+func (s schemaInferrer) schemaValue() avro.Schema {
+	if s.opts.structuredStruct() {
+		return avro.Nullable(s.structuredValueSchema())
+	}
 	return avro.Nullable(avro.String()) // EncodeJSON string
 }
 
 func (o *SchemaOptions) encodeValue(a *structpb.Value) (map[string]interface{}, error) {
+	if o.structuredStruct() {
+		return o.unionValue(wkt.Value, structuredValueJSON(a)), nil
+	}
 	data, err := protojson.Marshal(a)
 	if err != nil {
 		return nil, fmt.Errorf("google.protobuf.Struct: marshal: %w", err)
@@ -358,10 +529,13 @@ func (o *SchemaOptions) encodeValue(a *structpb.Value) (map[string]interface{},
 	return o.unionValue("string", string(data)), nil
 }
 
-func decodeValue(v map[string]interface{}) (*structpb.Value, error) {
+func (o *SchemaOptions) decodeValue(v map[string]interface{}) (*structpb.Value, error) {
 	if v == nil {
 		return nil, nil
 	}
+	if o.structuredStruct() {
+		return decodeStructuredValue(v)
+	}
 	str, err := decodeString(v, "string")
 	if err != nil {
 		return nil, fmt.Errorf("google.protobuf.Value: %w", err)
@@ -373,11 +547,20 @@ func decodeValue(v map[string]interface{}) (*structpb.Value, error) {
 	return &strct, nil
 }
 
-func schemaStruct() avro.Schema {
+func (s schemaInferrer) schemaStruct() avro.Schema {
+	if s.opts.structuredStruct() {
+		return avro.Nullable(avro.Map{
+			Type:   avro.MapType,
+			Values: s.structuredValueSchema(),
+		})
+	}
 	return avro.Nullable(avro.String()) // EncodeJSON string
 }
 
 func (o *SchemaOptions) encodeStruct(a *structpb.Struct) (map[string]interface{}, error) {
+	if o.structuredStruct() {
+		return o.unionValue("map", structuredStructJSON(a)), nil
+	}
 	data, err := protojson.Marshal(a)
 	if err != nil {
 		return nil, fmt.Errorf("google.protobuf.Struct: marshal: %w", err)
@@ -385,10 +568,13 @@ func (o *SchemaOptions) encodeStruct(a *structpb.Struct) (map[string]interface{}
 	return o.unionValue("string", string(data)), nil
 }
 
-func decodeStruct(v map[string]interface{}) (*structpb.Struct, error) {
+func (o *SchemaOptions) decodeStruct(v map[string]interface{}) (*structpb.Struct, error) {
 	if v == nil {
 		return nil, nil
 	}
+	if o.structuredStruct() {
+		return decodeStructuredStruct(v)
+	}
 	str, err := decodeString(v, "string")
 	if err != nil {
 		return nil, fmt.Errorf("google.protobuf.Struct: %w", err)
@@ -482,11 +668,15 @@ func decodeTimestamp(v map[string]interface{}) (*timestamppb.Timestamp, error) {
 }
 
 func decodeIntLike(v interface{}, key string) (int64, error) {
-	if i, ok := v.(int); ok {
+	switch i := v.(type) {
+	case int:
 		return int64(i), nil
-	}
-	if m, ok := v.(map[string]interface{}); ok {
-		return decodeInt(m, key)
+	case int32:
+		return int64(i), nil
+	case int64:
+		return i, nil
+	case map[string]interface{}:
+		return decodeInt(i, key)
 	}
 	return 0, fmt.Errorf("expected int-like, got %v", v)
 }
@@ -0,0 +1,396 @@
+package protoavro
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"go.einride.tech/protobuf-avro/avro"
+	"go.einride.tech/protobuf-avro/internal/wkt"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// structuredValueSchema returns the schema of google.protobuf.Value, as a record
+// with a single field holding a union of the kinds of values.
+func (s schemaInferrer) structuredValueSchema() avro.Schema {
+	if _, ok := s.seen[wkt.Value]; ok {
+		return avro.Reference(wkt.Value)
+	}
+	s.seen[wkt.Value] = ""
+	return avro.Record{
+		Type:      avro.RecordType,
+		Name:      "Value",
+		Namespace: "google.protobuf",
+		Fields: []avro.Field{
+			{
+				Name: "kind",
+				Type: avro.Union{
+					avro.Null(),
+					avro.Double(),
+					avro.String(),
+					avro.Boolean(),
+					avro.Array{Type: avro.ArrayType, Items: avro.Reference(wkt.Value)},
+					avro.Map{Type: avro.MapType, Values: avro.Reference(wkt.Value)},
+				},
+			},
+		},
+	}
+}
+
+func structuredValueJSON(v *structpb.Value) map[string]interface{} {
+	var kind interface{}
+	switch k := v.GetKind().(type) {
+	case *structpb.Value_NumberValue:
+		kind = map[string]interface{}{"double": k.NumberValue}
+	case *structpb.Value_StringValue:
+		kind = map[string]interface{}{"string": k.StringValue}
+	case *structpb.Value_BoolValue:
+		kind = map[string]interface{}{"boolean": k.BoolValue}
+	case *structpb.Value_ListValue:
+		list := make([]interface{}, 0, len(k.ListValue.GetValues()))
+		for _, value := range k.ListValue.GetValues() {
+			list = append(list, structuredValueJSON(value))
+		}
+		kind = map[string]interface{}{"array": list}
+	case *structpb.Value_StructValue:
+		kind = map[string]interface{}{"map": structuredStructJSON(k.StructValue)}
+	}
+	return map[string]interface{}{"kind": kind}
+}
+
+func structuredStructJSON(s *structpb.Struct) map[string]interface{} {
+	fields := make(map[string]interface{}, len(s.GetFields()))
+	for key, value := range s.GetFields() {
+		fields[key] = structuredValueJSON(value)
+	}
+	return fields
+}
+
+func decodeStructuredValue(v map[string]interface{}) (*structpb.Value, error) {
+	record, ok := v[wkt.Value]
+	if !ok {
+		return nil, fmt.Errorf("google.protobuf.Value: expected key '%s'", wkt.Value)
+	}
+	value, err := decodeStructuredValueRecord(record)
+	if err != nil {
+		return nil, fmt.Errorf("google.protobuf.Value: %w", err)
+	}
+	return value, nil
+}
+
+func decodeStructuredValueRecord(v interface{}) (*structpb.Value, error) {
+	record, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected record, got %T", v)
+	}
+	kind, ok := record["kind"].(map[string]interface{})
+	if !ok || len(kind) != 1 {
+		return structpb.NewNullValue(), nil
+	}
+	for key, value := range kind {
+		switch key {
+		case "double":
+			f, err := decodeFloatLike(kind, key)
+			if err != nil {
+				return nil, err
+			}
+			return structpb.NewNumberValue(f), nil
+		case "string":
+			str, err := decodeString(kind, key)
+			if err != nil {
+				return nil, err
+			}
+			return structpb.NewStringValue(str), nil
+		case "boolean":
+			b, err := decodeBool(kind, key)
+			if err != nil {
+				return nil, err
+			}
+			return structpb.NewBoolValue(b), nil
+		case "array":
+			items, err := decodeList(kind, key)
+			if err != nil {
+				return nil, err
+			}
+			list := &structpb.ListValue{Values: make([]*structpb.Value, 0, len(items))}
+			for _, item := range items {
+				element, err := decodeStructuredValueRecord(item)
+				if err != nil {
+					return nil, err
+				}
+				list.Values = append(list.Values, element)
+			}
+			return structpb.NewListValue(list), nil
+		case "map":
+			strct, err := decodeStructuredFields(value)
+			if err != nil {
+				return nil, err
+			}
+			return structpb.NewStructValue(strct), nil
+		default:
+			return nil, fmt.Errorf("unexpected kind '%s'", key)
+		}
+	}
+	return nil, nil
+}
+
+func decodeStructuredStruct(v map[string]interface{}) (*structpb.Struct, error) {
+	fields, ok := v["map"]
+	if !ok {
+		return nil, fmt.Errorf("google.protobuf.Struct: expected key 'map'")
+	}
+	strct, err := decodeStructuredFields(fields)
+	if err != nil {
+		return nil, fmt.Errorf("google.protobuf.Struct: %w", err)
+	}
+	return strct, nil
+}
+
+func decodeStructuredFields(v interface{}) (*structpb.Struct, error) {
+	fields, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected map, got %T", v)
+	}
+	strct := &structpb.Struct{Fields: make(map[string]*structpb.Value, len(fields))}
+	for key, field := range fields {
+		value, err := decodeStructuredValueRecord(field)
+		if err != nil {
+			return nil, err
+		}
+		strct.Fields[key] = value
+	}
+	return strct, nil
+}
+
+// structuredAnySchema returns the schema of google.protobuf.Any, either as a record with
+// the type URL and serialized value, or as a union of the message types in AnyTypes.
+// With UnknownAnyPreserve, the union also holds the record of the type URL and serialized
+// value, for values of other types.
+func (s schemaInferrer) structuredAnySchema(recursiveIndex int) (avro.Schema, error) {
+	if s.opts.AnyTypes == nil {
+		return avro.Nullable(s.rawAnySchema()), nil
+	}
+	union := avro.Union{avro.Null()}
+	for _, messageType := range anyMessageTypes(s.opts.AnyTypes) {
+		schema, err := s.inferMessageSchema(messageType.Descriptor(), nil, recursiveIndex+1)
+		if err != nil {
+			return nil, err
+		}
+		// inferred non-root messages are nullable
+		branch := nonNullable(schema)
+		if _, ok := branch.(avro.Union); ok {
+			return nil, fmt.Errorf("any type '%s': unions can not be nested", messageType.Descriptor().FullName())
+		}
+		union = append(union, branch)
+	}
+	if s.opts.UnknownAny == UnknownAnyPreserve {
+		union = append(union, s.rawAnySchema())
+	}
+	if s.opts.flattenUnions() {
+		return avro.Nullable(s.flattenedAnySchema(union[1:])), nil
+	}
+	return union, nil
+}
+
+// anyTypesName is the full name of the record that the union of the message types
+// in AnyTypes is flattened into.
+const anyTypesName = "google.protobuf.AnyTypes"
+
+// flattenedAnySchema returns the record with one nullable field per message type
+// in the union of google.protobuf.Any.
+func (s schemaInferrer) flattenedAnySchema(branches []avro.Schema) avro.Schema {
+	if _, ok := s.seen[anyTypesName]; ok {
+		return avro.Reference(anyTypesName)
+	}
+	s.seen[anyTypesName] = ""
+	fields := make([]avro.Field, 0, len(branches))
+	for _, branch := range branches {
+		var name string
+		switch b := branch.(type) {
+		case avro.Record:
+			name = b.Namespace + "." + b.Name
+		case avro.Reference:
+			name = string(b)
+		}
+		fields = append(fields, avro.Field{
+			Name:    flattenedFieldName(name),
+			Type:    avro.Nullable(branch),
+			Default: avro.NullDefault,
+		})
+	}
+	return avro.Record{
+		Type:      avro.RecordType,
+		Name:      "AnyTypes",
+		Namespace: "google.protobuf",
+		Fields:    fields,
+	}
+}
+
+// flattenAny returns the union value of google.protobuf.Any as the value of the flattened record.
+func (o SchemaOptions) flattenAny(value map[string]interface{}) map[string]interface{} {
+	fields := make(map[string]interface{})
+	for _, messageType := range anyMessageTypes(o.AnyTypes) {
+		fields[flattenedFieldName(o.avroFullName(messageType.Descriptor()))] = nil
+	}
+	if o.UnknownAny == UnknownAnyPreserve {
+		fields[flattenedFieldName(wkt.Any)] = nil
+	}
+	for name, v := range value {
+		fields[flattenedFieldName(name)] = map[string]interface{}{name: v}
+	}
+	return o.unionValue(anyTypesName, fields)
+}
+
+func (s schemaInferrer) rawAnySchema() avro.Schema {
+	if _, ok := s.seen[wkt.Any]; ok {
+		return avro.Reference(wkt.Any)
+	}
+	s.seen[wkt.Any] = ""
+	return avro.Record{
+		Type:      avro.RecordType,
+		Name:      "Any",
+		Namespace: "google.protobuf",
+		Fields: []avro.Field{
+			{Name: "type_url", Type: avro.String(), Default: ""},
+			{Name: "value", Type: avro.Bytes(), Default: ""},
+		},
+	}
+}
+
+// anyMessageTypes returns the message types in types that google.protobuf.Any
+// is expanded to, sorted by full name.
+// Well known types are not included, since several of them map to the same Avro type.
+func anyMessageTypes(types *protoregistry.Types) []protoreflect.MessageType {
+	var messageTypes []protoreflect.MessageType
+	types.RangeMessages(func(messageType protoreflect.MessageType) bool {
+		desc := messageType.Descriptor()
+		if !isWKT(desc.FullName()) && !desc.IsMapEntry() {
+			messageTypes = append(messageTypes, messageType)
+		}
+		return true
+	})
+	sort.Slice(messageTypes, func(i, j int) bool {
+		return messageTypes[i].Descriptor().FullName() < messageTypes[j].Descriptor().FullName()
+	})
+	return messageTypes
+}
+
+func (o SchemaOptions) encodeStructuredAny(a *anypb.Any) (interface{}, error) {
+	raw := map[string]interface{}{
+		"type_url": a.GetTypeUrl(),
+		"value":    a.GetValue(),
+	}
+	if o.AnyTypes == nil {
+		return o.unionValue(wkt.Any, raw), nil
+	}
+	value, err := o.encodeAnyTypes(a, raw)
+	if err != nil || !o.flattenUnions() {
+		return value, err
+	}
+	return o.flattenAny(value), nil
+}
+
+// encodeAnyTypes returns the value of google.protobuf.Any in the union of the message types in AnyTypes.
+func (o SchemaOptions) encodeAnyTypes(a *anypb.Any, raw map[string]interface{}) (map[string]interface{}, error) {
+	messageType, err := o.AnyTypes.FindMessageByURL(a.GetTypeUrl())
+	if errors.Is(err, protoregistry.NotFound) && o.UnknownAny == UnknownAnyPreserve {
+		return map[string]interface{}{wkt.Any: raw}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("google.protobuf.Any: find type %s: %w", a.GetTypeUrl(), err)
+	}
+	if isWKT(messageType.Descriptor().FullName()) {
+		return nil, fmt.Errorf("google.protobuf.Any: unsupported well known type %s", messageType.Descriptor().FullName())
+	}
+	message := messageType.New()
+	if err := (proto.UnmarshalOptions{Resolver: o.AnyTypes}).Unmarshal(a.GetValue(), message.Interface()); err != nil {
+		return nil, fmt.Errorf("google.protobuf.Any: unmarshal: %w", err)
+	}
+	value, err := o.messageJSON(message, nil, 1, true)
+	if err != nil {
+		return nil, err
+	}
+	union, _ := value.(map[string]interface{})
+	return union, nil
+}
+
+func (o *SchemaOptions) decodeStructuredAny(v map[string]interface{}) (*anypb.Any, error) {
+	if o.AnyTypes == nil {
+		return decodeRawAny(v[wkt.Any])
+	}
+	if o.flattenUnions() {
+		var err error
+		if v, err = unflattenAny(v); err != nil || v == nil {
+			return nil, err
+		}
+	}
+	if len(v) != 1 {
+		return nil, fmt.Errorf("google.protobuf.Any: expected union with a single key, got %d", len(v))
+	}
+	for name, data := range v {
+		if name == wkt.Any {
+			return decodeRawAny(data)
+		}
+		messageType, err := o.findAnyMessageType(name)
+		if err != nil {
+			return nil, fmt.Errorf("google.protobuf.Any: find type %s: %w", name, err)
+		}
+		message := messageType.New()
+		if err := o.decodeMessage(data, message, nil); err != nil {
+			return nil, fmt.Errorf("google.protobuf.Any: %w", err)
+		}
+		a, err := anypb.New(message.Interface())
+		if err != nil {
+			return nil, fmt.Errorf("google.protobuf.Any: %w", err)
+		}
+		return a, nil
+	}
+	return nil, nil
+}
+
+// unflattenAny returns the union value of google.protobuf.Any from the value of the flattened record.
+func unflattenAny(v map[string]interface{}) (map[string]interface{}, error) {
+	fields, ok := v[anyTypesName].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("google.protobuf.Any: expected key '%s'", anyTypesName)
+	}
+	for _, field := range fields {
+		if union, ok := field.(map[string]interface{}); ok {
+			return union, nil
+		}
+	}
+	return nil, nil
+}
+
+// findAnyMessageType returns the message type in AnyTypes with the Avro full name.
+func (o *SchemaOptions) findAnyMessageType(name string) (protoreflect.MessageType, error) {
+	if !o.SanitizeNames {
+		return o.AnyTypes.FindMessageByName(protoreflect.FullName(name))
+	}
+	for _, messageType := range anyMessageTypes(o.AnyTypes) {
+		if o.avroFullName(messageType.Descriptor()) == name {
+			return messageType, nil
+		}
+	}
+	return nil, protoregistry.NotFound
+}
+
+func decodeRawAny(v interface{}) (*anypb.Any, error) {
+	record, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("google.protobuf.Any: expected key '%s'", wkt.Any)
+	}
+	typeURL, err := decodeStringLike(record["type_url"], "string")
+	if err != nil {
+		return nil, fmt.Errorf("google.protobuf.Any: type_url: %w", err)
+	}
+	value, err := decodeBytesLike(record["value"], "bytes")
+	if err != nil {
+		return nil, fmt.Errorf("google.protobuf.Any: value: %w", err)
+	}
+	return &anypb.Any{TypeUrl: typeURL, Value: value}, nil
+}
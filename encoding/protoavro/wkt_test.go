@@ -1,14 +1,23 @@
 package protoavro
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 
+	"github.com/linkedin/goavro/v2"
+	"go.einride.tech/protobuf-avro/avro"
+	examplev1 "go.einride.tech/protobuf-avro/internal/examples/proto/gen/einride/avro/example/v1"
 	"google.golang.org/genproto/googleapis/example/library/v1"
 	"google.golang.org/genproto/googleapis/type/date"
 	"google.golang.org/genproto/googleapis/type/timeofday"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
 	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/structpb"
@@ -73,7 +82,7 @@ func Test_WKT(t *testing.T) {
 			assert.NilError(t, err)
 			t.Log(encoded)
 			decoded := tt.ProtoReflect().New()
-			assert.NilError(t, decodeWKT(encoded.(map[string]interface{}), decoded))
+			assert.NilError(t, (&SchemaOptions{}).decodeWKT(encoded.(map[string]interface{}), decoded))
 			assert.DeepEqual(t, tt, decoded.Interface(), protocmp.Transform())
 		})
 	}
@@ -147,8 +156,292 @@ func Test_DecodeWKTErr(t *testing.T) {
 	} {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
-			err := decodeWKT(tt.data, tt.msg.ProtoReflect())
+			err := (&SchemaOptions{}).decodeWKT(tt.data, tt.msg.ProtoReflect())
 			assert.ErrorContains(t, err, tt.errContains)
 		})
 	}
 }
+
+func Test_StructuredWKT(t *testing.T) {
+	types := new(protoregistry.Types)
+	assert.NilError(t, types.RegisterMessage((&library.Book{}).ProtoReflect().Type()))
+	assert.NilError(t, types.RegisterMessage((&examplev1.ExampleEnum{}).ProtoReflect().Type()))
+	for _, tt := range []struct {
+		name string
+		opts SchemaOptions
+		msg  proto.Message
+	}{
+		{
+			name: "struct",
+			opts: SchemaOptions{StructuredWKT: true},
+			msg: &examplev1.ExampleStruct{
+				Struct: &structpb.Struct{
+					Fields: map[string]*structpb.Value{
+						"null":    structpb.NewNullValue(),
+						"number":  structpb.NewNumberValue(123.456),
+						"string":  structpb.NewStringValue("value"),
+						"boolean": structpb.NewBoolValue(true),
+						"list": structpb.NewListValue(&structpb.ListValue{
+							Values: []*structpb.Value{
+								structpb.NewStringValue("value"),
+								structpb.NewListValue(&structpb.ListValue{}),
+							},
+						}),
+						"struct": structpb.NewStructValue(&structpb.Struct{
+							Fields: map[string]*structpb.Value{
+								"number": structpb.NewNumberValue(1),
+							},
+						}),
+					},
+				},
+			},
+		},
+		{
+			name: "any record",
+			opts: SchemaOptions{StructuredWKT: true},
+			msg: &examplev1.ExampleAny{
+				Any: mustAny(t, &library.Book{Name: "shelves/1/books/1"}),
+			},
+		},
+		{
+			name: "any union",
+			opts: SchemaOptions{StructuredWKT: true, AnyTypes: types},
+			msg: &examplev1.ExampleAny{
+				Any: mustAny(t, &library.Book{Name: "shelves/1/books/1"}),
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			schema, err := tt.opts.InferSchema(tt.msg.ProtoReflect().Descriptor())
+			assert.NilError(t, err)
+			schemaBytes, err := json.Marshal(schema)
+			assert.NilError(t, err)
+			codec, err := goavro.NewCodec(string(schemaBytes))
+			assert.NilError(t, err)
+
+			encoded, err := tt.opts.encodeJSON(tt.msg)
+			assert.NilError(t, err)
+			binary, err := codec.BinaryFromNative(nil, encoded)
+			assert.NilError(t, err)
+			native, _, err := codec.NativeFromBinary(binary)
+			assert.NilError(t, err)
+
+			decoded := tt.msg.ProtoReflect().New().Interface()
+			assert.NilError(t, tt.opts.decodeJSON(native, decoded))
+			assert.DeepEqual(t, tt.msg, decoded, protocmp.Transform())
+		})
+	}
+
+	t.Run("any union schema", func(t *testing.T) {
+		opts := SchemaOptions{OmitRootElement: true, StructuredWKT: true, AnyTypes: types}
+		schema, err := opts.InferSchema((&examplev1.ExampleAny{}).ProtoReflect().Descriptor())
+		assert.NilError(t, err)
+		assert.DeepEqual(t, avro.Record{
+			Type:      avro.RecordType,
+			Name:      "ExampleAny",
+			Namespace: "einride.avro.example.v1",
+			Fields: []avro.Field{
+				{
+					Name: "any",
+					Type: avro.Union{
+						avro.Null(),
+						avro.Record{
+							Type:      avro.RecordType,
+							Name:      "ExampleEnum",
+							Namespace: "einride.avro.example.v1",
+							Fields: []avro.Field{
+								{
+									Name: "enum_value",
+									Type: avro.Nullable(avro.Enum{
+										Type:      avro.EnumType,
+										Name:      "Enum",
+										Namespace: "einride.avro.example.v1.ExampleEnum",
+										Symbols:   []string{"ENUM_UNSPECIFIED", "ENUM_VALUE1", "ENUM_VALUE2", "ENUM_VALUE3"},
+										Default:   "ENUM_UNSPECIFIED",
+									}),
+									Default: avro.NullDefault,
+									Props:   numberProps(1),
+								},
+							},
+						},
+						avro.Record{
+							Type:      avro.RecordType,
+							Name:      "Book",
+							Namespace: "google.example.library.v1",
+							Fields: []avro.Field{
+								{Name: "name", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(1)},
+								{Name: "author", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(2)},
+								{Name: "title", Type: avro.Nullable(avro.String()), Default: avro.NullDefault, Props: numberProps(3)},
+								{Name: "read", Type: avro.Nullable(avro.Boolean()), Default: avro.NullDefault, Props: numberProps(4)},
+							},
+						},
+					},
+					Default: avro.NullDefault,
+					Props:   numberProps(1),
+				},
+			},
+		}, schema)
+	})
+}
+
+func Test_AnyResolver(t *testing.T) {
+	books := new(protoregistry.Types)
+	assert.NilError(t, books.RegisterMessage((&library.Book{}).ProtoReflect().Type()))
+	known := mustAny(t, &library.Book{Name: "shelves/1/books/1"})
+	unknown := &anypb.Any{TypeUrl: "type.googleapis.com/example.v1.Unknown", Value: []byte{0x0a, 0x01, 0x61}}
+	for _, tt := range []struct {
+		name        string
+		opts        SchemaOptions
+		any         *anypb.Any
+		errContains string
+	}{
+		{
+			name: "custom resolver",
+			opts: SchemaOptions{AnyResolver: books},
+			any:  known,
+		},
+		{
+			name:        "custom resolver without type",
+			opts:        SchemaOptions{AnyResolver: new(protoregistry.Types)},
+			any:         known,
+			errContains: "google.protobuf.Any: marshal",
+		},
+		{
+			name:        "unknown type",
+			any:         unknown,
+			errContains: "google.protobuf.Any: marshal",
+		},
+		{
+			name: "unknown type preserved",
+			opts: SchemaOptions{UnknownAny: UnknownAnyPreserve},
+			any:  unknown,
+		},
+		{
+			name: "unknown type preserved in structured union",
+			opts: SchemaOptions{StructuredWKT: true, AnyTypes: books, UnknownAny: UnknownAnyPreserve},
+			any:  unknown,
+		},
+		{
+			name:        "unknown type in structured union",
+			opts:        SchemaOptions{StructuredWKT: true, AnyTypes: books},
+			any:         unknown,
+			errContains: "google.protobuf.Any: find type",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			msg := &examplev1.ExampleAny{Any: tt.any}
+			schema, err := tt.opts.InferSchema(msg.ProtoReflect().Descriptor())
+			assert.NilError(t, err)
+			schemaBytes, err := json.Marshal(schema)
+			assert.NilError(t, err)
+			codec, err := goavro.NewCodec(string(schemaBytes))
+			assert.NilError(t, err)
+
+			encoded, err := tt.opts.encodeJSON(msg)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			assert.NilError(t, err)
+			binary, err := codec.BinaryFromNative(nil, encoded)
+			assert.NilError(t, err)
+			native, _, err := codec.NativeFromBinary(binary)
+			assert.NilError(t, err)
+
+			var decoded examplev1.ExampleAny
+			assert.NilError(t, tt.opts.decodeJSON(native, &decoded))
+			assert.DeepEqual(t, msg, &decoded, protocmp.Transform())
+		})
+	}
+}
+
+func Test_AnyResolver_Preserved(t *testing.T) {
+	// written without the type of the value, and read with it
+	writer := SchemaOptions{AnyResolver: new(protoregistry.Types), UnknownAny: UnknownAnyPreserve}
+	msg := &examplev1.ExampleAny{Any: mustAny(t, &library.Book{Name: "shelves/1/books/1"})}
+	encoded, err := writer.encodeJSON(msg)
+	assert.NilError(t, err)
+	for _, tt := range []struct {
+		name        string
+		opts        SchemaOptions
+		errContains string
+	}{
+		{
+			name: "reader with type",
+			opts: SchemaOptions{},
+		},
+		{
+			name: "reader without type",
+			opts: writer,
+		},
+		{
+			name:        "reader without type that fails",
+			opts:        SchemaOptions{AnyResolver: new(protoregistry.Types)},
+			errContains: "google.protobuf.Any: find type type.googleapis.com/google.example.library.v1.Book",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var decoded examplev1.ExampleAny
+			err := tt.opts.decodeJSON(encoded, &decoded)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, msg, &decoded, protocmp.Transform())
+		})
+	}
+}
+
+func Test_DynamicWKT(t *testing.T) {
+	// build descriptors, including the well known types, that are distinct from the generated ones
+	var fileSet descriptorpb.FileDescriptorSet
+	for _, file := range []protoreflect.FileDescriptor{
+		timestamppb.File_google_protobuf_timestamp_proto,
+		wrapperspb.File_google_protobuf_wrappers_proto,
+		structpb.File_google_protobuf_struct_proto,
+		examplev1.File_einride_avro_example_v1_example_timestamp_proto,
+		examplev1.File_einride_avro_example_v1_example_wrappers_proto,
+		examplev1.File_einride_avro_example_v1_example_struct_proto,
+	} {
+		fileSet.File = append(fileSet.File, protodesc.ToFileDescriptorProto(file))
+	}
+	files, err := protodesc.NewFiles(&fileSet)
+	assert.NilError(t, err)
+
+	for _, msg := range []proto.Message{
+		&examplev1.ExampleTimestamp{Timestamp: timestamppb.New(time.Unix(1, 2000))},
+		&examplev1.ExampleWrappers{DoubleValue: wrapperspb.Double(1.5), StringValue: wrapperspb.String("value")},
+		&examplev1.ExampleStruct{Struct: &structpb.Struct{
+			Fields: map[string]*structpb.Value{"number": structpb.NewNumberValue(1)},
+		}},
+	} {
+		msg := msg
+		t.Run(string(msg.ProtoReflect().Descriptor().Name()), func(t *testing.T) {
+			desc, err := files.FindDescriptorByName(msg.ProtoReflect().Descriptor().FullName())
+			assert.NilError(t, err)
+			dynamic := dynamicpb.NewMessage(desc.(protoreflect.MessageDescriptor))
+			data, err := proto.Marshal(msg)
+			assert.NilError(t, err)
+			assert.NilError(t, proto.Unmarshal(data, dynamic))
+
+			opts := SchemaOptions{}
+			encoded, err := opts.encodeJSON(dynamic)
+			assert.NilError(t, err)
+			expected, err := opts.encodeJSON(msg)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, expected, encoded)
+
+			decoded := dynamicpb.NewMessage(desc.(protoreflect.MessageDescriptor))
+			assert.NilError(t, opts.decodeJSON(encoded, decoded))
+			data, err = proto.Marshal(decoded)
+			assert.NilError(t, err)
+			got := msg.ProtoReflect().New().Interface()
+			assert.NilError(t, proto.Unmarshal(data, got))
+			assert.DeepEqual(t, msg, got, protocmp.Transform())
+		})
+	}
+}
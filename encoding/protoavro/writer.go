@@ -0,0 +1,37 @@
+package protoavro
+
+import (
+	"fmt"
+	"io"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// NewWriter returns a new writer that writes protobuf messages of type T to w in
+// Avro binary format. The schema is inferred from the descriptor of T, which must be
+// a generated message type, such as *library.Book.
+func NewWriter[T proto.Message](w io.Writer, opts SchemaOptions) (*Writer[T], error) {
+	mt, err := messageType[T]()
+	if err != nil {
+		return nil, err
+	}
+	m, err := opts.NewMarshaler(mt.Descriptor(), w)
+	if err != nil {
+		return nil, fmt.Errorf("new marshaler: %w", err)
+	}
+	return &Writer[T]{m: m}, nil
+}
+
+// Writer encodes and writes Avro binary encoded messages of type T.
+type Writer[T proto.Message] struct {
+	m *Marshaler
+}
+
+// Write encodes and writes messages to the writer.
+func (w *Writer[T]) Write(messages ...T) error {
+	data := make([]proto.Message, 0, len(messages))
+	for _, message := range messages {
+		data = append(data, message)
+	}
+	return w.m.marshal(data)
+}
@@ -0,0 +1,426 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: einride/avro/example/v1/example_editions.proto
+
+package examplev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ExampleEditions_ClosedEnum int32
+
+const (
+	ExampleEditions_CLOSED_VALUE1 ExampleEditions_ClosedEnum = 1
+	ExampleEditions_CLOSED_VALUE2 ExampleEditions_ClosedEnum = 2
+)
+
+// Enum value maps for ExampleEditions_ClosedEnum.
+var (
+	ExampleEditions_ClosedEnum_name = map[int32]string{
+		1: "CLOSED_VALUE1",
+		2: "CLOSED_VALUE2",
+	}
+	ExampleEditions_ClosedEnum_value = map[string]int32{
+		"CLOSED_VALUE1": 1,
+		"CLOSED_VALUE2": 2,
+	}
+)
+
+func (x ExampleEditions_ClosedEnum) Enum() *ExampleEditions_ClosedEnum {
+	p := new(ExampleEditions_ClosedEnum)
+	*p = x
+	return p
+}
+
+func (x ExampleEditions_ClosedEnum) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExampleEditions_ClosedEnum) Descriptor() protoreflect.EnumDescriptor {
+	return file_einride_avro_example_v1_example_editions_proto_enumTypes[0].Descriptor()
+}
+
+func (ExampleEditions_ClosedEnum) Type() protoreflect.EnumType {
+	return &file_einride_avro_example_v1_example_editions_proto_enumTypes[0]
+}
+
+func (x ExampleEditions_ClosedEnum) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExampleEditions_ClosedEnum.Descriptor instead.
+func (ExampleEditions_ClosedEnum) EnumDescriptor() ([]byte, []int) {
+	return file_einride_avro_example_v1_example_editions_proto_rawDescGZIP(), []int{0, 0}
+}
+
+type ExampleEditions_OpenEnum int32
+
+const (
+	ExampleEditions_OPEN_VALUE_UNSPECIFIED ExampleEditions_OpenEnum = 0
+	ExampleEditions_OPEN_VALUE1            ExampleEditions_OpenEnum = 1
+)
+
+// Enum value maps for ExampleEditions_OpenEnum.
+var (
+	ExampleEditions_OpenEnum_name = map[int32]string{
+		0: "OPEN_VALUE_UNSPECIFIED",
+		1: "OPEN_VALUE1",
+	}
+	ExampleEditions_OpenEnum_value = map[string]int32{
+		"OPEN_VALUE_UNSPECIFIED": 0,
+		"OPEN_VALUE1":            1,
+	}
+)
+
+func (x ExampleEditions_OpenEnum) Enum() *ExampleEditions_OpenEnum {
+	p := new(ExampleEditions_OpenEnum)
+	*p = x
+	return p
+}
+
+func (x ExampleEditions_OpenEnum) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExampleEditions_OpenEnum) Descriptor() protoreflect.EnumDescriptor {
+	return file_einride_avro_example_v1_example_editions_proto_enumTypes[1].Descriptor()
+}
+
+func (ExampleEditions_OpenEnum) Type() protoreflect.EnumType {
+	return &file_einride_avro_example_v1_example_editions_proto_enumTypes[1]
+}
+
+func (x ExampleEditions_OpenEnum) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExampleEditions_OpenEnum.Descriptor instead.
+func (ExampleEditions_OpenEnum) EnumDescriptor() ([]byte, []int) {
+	return file_einride_avro_example_v1_example_editions_proto_rawDescGZIP(), []int{0, 1}
+}
+
+type ExampleEditions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Fields have explicit presence by default.
+	ExplicitString   *string                     `protobuf:"bytes,1,opt,name=explicit_string,json=explicitString" json:"explicit_string,omitempty"`
+	ImplicitInt32    int32                       `protobuf:"varint,2,opt,name=implicit_int32,json=implicitInt32" json:"implicit_int32,omitempty"`
+	RequiredString   *string                     `protobuf:"bytes,3,req,name=required_string,json=requiredString" json:"required_string,omitempty"`
+	DefaultInt32     *int32                      `protobuf:"varint,4,opt,name=default_int32,json=defaultInt32,def=7" json:"default_int32,omitempty"`
+	ClosedEnum       *ExampleEditions_ClosedEnum `protobuf:"varint,5,opt,name=closed_enum,json=closedEnum,enum=einride.avro.example.v1.ExampleEditions_ClosedEnum" json:"closed_enum,omitempty"`
+	OpenEnum         *ExampleEditions_OpenEnum   `protobuf:"varint,6,opt,name=open_enum,json=openEnum,enum=einride.avro.example.v1.ExampleEditions_OpenEnum" json:"open_enum,omitempty"`
+	ImplicitOpenEnum ExampleEditions_OpenEnum    `protobuf:"varint,7,opt,name=implicit_open_enum,json=implicitOpenEnum,enum=einride.avro.example.v1.ExampleEditions_OpenEnum" json:"implicit_open_enum,omitempty"`
+	RepeatedInt64    []int64                     `protobuf:"varint,8,rep,packed,name=repeated_int64,json=repeatedInt64" json:"repeated_int64,omitempty"`
+	DelimitedMessage *ExampleEditions_Nested     `protobuf:"group,9,opt,name=Nested,json=delimitedMessage" json:"delimited_message,omitempty"`
+}
+
+// Default values for ExampleEditions fields.
+const (
+	Default_ExampleEditions_DefaultInt32 = int32(7)
+)
+
+func (x *ExampleEditions) Reset() {
+	*x = ExampleEditions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_einride_avro_example_v1_example_editions_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExampleEditions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExampleEditions) ProtoMessage() {}
+
+func (x *ExampleEditions) ProtoReflect() protoreflect.Message {
+	mi := &file_einride_avro_example_v1_example_editions_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExampleEditions.ProtoReflect.Descriptor instead.
+func (*ExampleEditions) Descriptor() ([]byte, []int) {
+	return file_einride_avro_example_v1_example_editions_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ExampleEditions) GetExplicitString() string {
+	if x != nil && x.ExplicitString != nil {
+		return *x.ExplicitString
+	}
+	return ""
+}
+
+func (x *ExampleEditions) GetImplicitInt32() int32 {
+	if x != nil {
+		return x.ImplicitInt32
+	}
+	return 0
+}
+
+func (x *ExampleEditions) GetRequiredString() string {
+	if x != nil && x.RequiredString != nil {
+		return *x.RequiredString
+	}
+	return ""
+}
+
+func (x *ExampleEditions) GetDefaultInt32() int32 {
+	if x != nil && x.DefaultInt32 != nil {
+		return *x.DefaultInt32
+	}
+	return Default_ExampleEditions_DefaultInt32
+}
+
+func (x *ExampleEditions) GetClosedEnum() ExampleEditions_ClosedEnum {
+	if x != nil && x.ClosedEnum != nil {
+		return *x.ClosedEnum
+	}
+	return ExampleEditions_CLOSED_VALUE1
+}
+
+func (x *ExampleEditions) GetOpenEnum() ExampleEditions_OpenEnum {
+	if x != nil && x.OpenEnum != nil {
+		return *x.OpenEnum
+	}
+	return ExampleEditions_OPEN_VALUE_UNSPECIFIED
+}
+
+func (x *ExampleEditions) GetImplicitOpenEnum() ExampleEditions_OpenEnum {
+	if x != nil {
+		return x.ImplicitOpenEnum
+	}
+	return ExampleEditions_OPEN_VALUE_UNSPECIFIED
+}
+
+func (x *ExampleEditions) GetRepeatedInt64() []int64 {
+	if x != nil {
+		return x.RepeatedInt64
+	}
+	return nil
+}
+
+func (x *ExampleEditions) GetDelimitedMessage() *ExampleEditions_Nested {
+	if x != nil {
+		return x.DelimitedMessage
+	}
+	return nil
+}
+
+type ExampleEditions_Nested struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NestedString *string `protobuf:"bytes,1,opt,name=nested_string,json=nestedString" json:"nested_string,omitempty"`
+}
+
+func (x *ExampleEditions_Nested) Reset() {
+	*x = ExampleEditions_Nested{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_einride_avro_example_v1_example_editions_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExampleEditions_Nested) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExampleEditions_Nested) ProtoMessage() {}
+
+func (x *ExampleEditions_Nested) ProtoReflect() protoreflect.Message {
+	mi := &file_einride_avro_example_v1_example_editions_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExampleEditions_Nested.ProtoReflect.Descriptor instead.
+func (*ExampleEditions_Nested) Descriptor() ([]byte, []int) {
+	return file_einride_avro_example_v1_example_editions_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *ExampleEditions_Nested) GetNestedString() string {
+	if x != nil && x.NestedString != nil {
+		return *x.NestedString
+	}
+	return ""
+}
+
+var File_einride_avro_example_v1_example_editions_proto protoreflect.FileDescriptor
+
+var file_einride_avro_example_v1_example_editions_proto_rawDesc = []byte{
+	0x0a, 0x2e, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2f, 0x61, 0x76, 0x72, 0x6f, 0x2f, 0x65,
+	0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x5f, 0x65, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x17, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x61, 0x76, 0x72, 0x6f, 0x2e, 0x65,
+	0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x22, 0xfc, 0x05, 0x0a, 0x0f, 0x45, 0x78,
+	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x45, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x27, 0x0a,
+	0x0f, 0x65, 0x78, 0x70, 0x6c, 0x69, 0x63, 0x69, 0x74, 0x5f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x65, 0x78, 0x70, 0x6c, 0x69, 0x63, 0x69, 0x74,
+	0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x2c, 0x0a, 0x0e, 0x69, 0x6d, 0x70, 0x6c, 0x69, 0x63,
+	0x69, 0x74, 0x5f, 0x69, 0x6e, 0x74, 0x33, 0x32, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x42, 0x05,
+	0xaa, 0x01, 0x02, 0x08, 0x02, 0x52, 0x0d, 0x69, 0x6d, 0x70, 0x6c, 0x69, 0x63, 0x69, 0x74, 0x49,
+	0x6e, 0x74, 0x33, 0x32, 0x12, 0x2e, 0x0a, 0x0f, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64,
+	0x5f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x42, 0x05, 0xaa,
+	0x01, 0x02, 0x08, 0x03, 0x52, 0x0e, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x53, 0x74,
+	0x72, 0x69, 0x6e, 0x67, 0x12, 0x26, 0x0a, 0x0d, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f,
+	0x69, 0x6e, 0x74, 0x33, 0x32, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x3a, 0x01, 0x37, 0x52, 0x0c,
+	0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x49, 0x6e, 0x74, 0x33, 0x32, 0x12, 0x54, 0x0a, 0x0b,
+	0x63, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x5f, 0x65, 0x6e, 0x75, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x33, 0x2e, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x61, 0x76, 0x72, 0x6f,
+	0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x61, 0x6d,
+	0x70, 0x6c, 0x65, 0x45, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x43, 0x6c, 0x6f, 0x73,
+	0x65, 0x64, 0x45, 0x6e, 0x75, 0x6d, 0x52, 0x0a, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x45, 0x6e,
+	0x75, 0x6d, 0x12, 0x4e, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x6e, 0x5f, 0x65, 0x6e, 0x75, 0x6d, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x31, 0x2e, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2e,
+	0x61, 0x76, 0x72, 0x6f, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x45, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e,
+	0x4f, 0x70, 0x65, 0x6e, 0x45, 0x6e, 0x75, 0x6d, 0x52, 0x08, 0x6f, 0x70, 0x65, 0x6e, 0x45, 0x6e,
+	0x75, 0x6d, 0x12, 0x66, 0x0a, 0x12, 0x69, 0x6d, 0x70, 0x6c, 0x69, 0x63, 0x69, 0x74, 0x5f, 0x6f,
+	0x70, 0x65, 0x6e, 0x5f, 0x65, 0x6e, 0x75, 0x6d, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x31,
+	0x2e, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x61, 0x76, 0x72, 0x6f, 0x2e, 0x65, 0x78,
+	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65,
+	0x45, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x45, 0x6e, 0x75,
+	0x6d, 0x42, 0x05, 0xaa, 0x01, 0x02, 0x08, 0x02, 0x52, 0x10, 0x69, 0x6d, 0x70, 0x6c, 0x69, 0x63,
+	0x69, 0x74, 0x4f, 0x70, 0x65, 0x6e, 0x45, 0x6e, 0x75, 0x6d, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65,
+	0x70, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x69, 0x6e, 0x74, 0x36, 0x34, 0x18, 0x08, 0x20, 0x03,
+	0x28, 0x03, 0x52, 0x0d, 0x72, 0x65, 0x70, 0x65, 0x61, 0x74, 0x65, 0x64, 0x49, 0x6e, 0x74, 0x36,
+	0x34, 0x12, 0x63, 0x0a, 0x11, 0x64, 0x65, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x64, 0x5f, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x65,
+	0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x61, 0x76, 0x72, 0x6f, 0x2e, 0x65, 0x78, 0x61, 0x6d,
+	0x70, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x45, 0x64,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x42, 0x05, 0xaa,
+	0x01, 0x02, 0x28, 0x02, 0x52, 0x10, 0x64, 0x65, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x64, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x1a, 0x2d, 0x0a, 0x06, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64,
+	0x12, 0x23, 0x0a, 0x0d, 0x6e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x5f, 0x73, 0x74, 0x72, 0x69, 0x6e,
+	0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x6e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x53,
+	0x74, 0x72, 0x69, 0x6e, 0x67, 0x22, 0x38, 0x0a, 0x0a, 0x43, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x45,
+	0x6e, 0x75, 0x6d, 0x12, 0x11, 0x0a, 0x0d, 0x43, 0x4c, 0x4f, 0x53, 0x45, 0x44, 0x5f, 0x56, 0x41,
+	0x4c, 0x55, 0x45, 0x31, 0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d, 0x43, 0x4c, 0x4f, 0x53, 0x45, 0x44,
+	0x5f, 0x56, 0x41, 0x4c, 0x55, 0x45, 0x32, 0x10, 0x02, 0x1a, 0x04, 0x3a, 0x02, 0x10, 0x02, 0x22,
+	0x37, 0x0a, 0x08, 0x4f, 0x70, 0x65, 0x6e, 0x45, 0x6e, 0x75, 0x6d, 0x12, 0x1a, 0x0a, 0x16, 0x4f,
+	0x50, 0x45, 0x4e, 0x5f, 0x56, 0x41, 0x4c, 0x55, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
+	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x4f, 0x50, 0x45, 0x4e, 0x5f,
+	0x56, 0x41, 0x4c, 0x55, 0x45, 0x31, 0x10, 0x01, 0x42, 0x5d, 0x5a, 0x5b, 0x67, 0x6f, 0x2e, 0x65,
+	0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x74, 0x65, 0x63, 0x68, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2d, 0x61, 0x76, 0x72, 0x6f, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e,
+	0x61, 0x6c, 0x2f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2f, 0x61, 0x76,
+	0x72, 0x6f, 0x2f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2f, 0x76, 0x31, 0x3b, 0x65, 0x78,
+	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x76, 0x31, 0x62, 0x08, 0x65, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x70, 0xe8, 0x07,
+}
+
+var (
+	file_einride_avro_example_v1_example_editions_proto_rawDescOnce sync.Once
+	file_einride_avro_example_v1_example_editions_proto_rawDescData = file_einride_avro_example_v1_example_editions_proto_rawDesc
+)
+
+func file_einride_avro_example_v1_example_editions_proto_rawDescGZIP() []byte {
+	file_einride_avro_example_v1_example_editions_proto_rawDescOnce.Do(func() {
+		file_einride_avro_example_v1_example_editions_proto_rawDescData = protoimpl.X.CompressGZIP(file_einride_avro_example_v1_example_editions_proto_rawDescData)
+	})
+	return file_einride_avro_example_v1_example_editions_proto_rawDescData
+}
+
+var file_einride_avro_example_v1_example_editions_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_einride_avro_example_v1_example_editions_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_einride_avro_example_v1_example_editions_proto_goTypes = []any{
+	(ExampleEditions_ClosedEnum)(0), // 0: einride.avro.example.v1.ExampleEditions.ClosedEnum
+	(ExampleEditions_OpenEnum)(0),   // 1: einride.avro.example.v1.ExampleEditions.OpenEnum
+	(*ExampleEditions)(nil),         // 2: einride.avro.example.v1.ExampleEditions
+	(*ExampleEditions_Nested)(nil),  // 3: einride.avro.example.v1.ExampleEditions.Nested
+}
+var file_einride_avro_example_v1_example_editions_proto_depIdxs = []int32{
+	0, // 0: einride.avro.example.v1.ExampleEditions.closed_enum:type_name -> einride.avro.example.v1.ExampleEditions.ClosedEnum
+	1, // 1: einride.avro.example.v1.ExampleEditions.open_enum:type_name -> einride.avro.example.v1.ExampleEditions.OpenEnum
+	1, // 2: einride.avro.example.v1.ExampleEditions.implicit_open_enum:type_name -> einride.avro.example.v1.ExampleEditions.OpenEnum
+	3, // 3: einride.avro.example.v1.ExampleEditions.delimited_message:type_name -> einride.avro.example.v1.ExampleEditions.Nested
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_einride_avro_example_v1_example_editions_proto_init() }
+func file_einride_avro_example_v1_example_editions_proto_init() {
+	if File_einride_avro_example_v1_example_editions_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_einride_avro_example_v1_example_editions_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*ExampleEditions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_einride_avro_example_v1_example_editions_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*ExampleEditions_Nested); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_einride_avro_example_v1_example_editions_proto_rawDesc,
+			NumEnums:      2,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_einride_avro_example_v1_example_editions_proto_goTypes,
+		DependencyIndexes: file_einride_avro_example_v1_example_editions_proto_depIdxs,
+		EnumInfos:         file_einride_avro_example_v1_example_editions_proto_enumTypes,
+		MessageInfos:      file_einride_avro_example_v1_example_editions_proto_msgTypes,
+	}.Build()
+	File_einride_avro_example_v1_example_editions_proto = out.File
+	file_einride_avro_example_v1_example_editions_proto_rawDesc = nil
+	file_einride_avro_example_v1_example_editions_proto_goTypes = nil
+	file_einride_avro_example_v1_example_editions_proto_depIdxs = nil
+}
@@ -0,0 +1,335 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: einride/avro/example/v1/example_editions_file_features.proto
+
+package examplev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type FileEnum int32
+
+const (
+	FileEnum_FILE_VALUE1 FileEnum = 1
+	FileEnum_FILE_VALUE2 FileEnum = 2
+)
+
+// Enum value maps for FileEnum.
+var (
+	FileEnum_name = map[int32]string{
+		1: "FILE_VALUE1",
+		2: "FILE_VALUE2",
+	}
+	FileEnum_value = map[string]int32{
+		"FILE_VALUE1": 1,
+		"FILE_VALUE2": 2,
+	}
+)
+
+func (x FileEnum) Enum() *FileEnum {
+	p := new(FileEnum)
+	*p = x
+	return p
+}
+
+func (x FileEnum) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FileEnum) Descriptor() protoreflect.EnumDescriptor {
+	return file_einride_avro_example_v1_example_editions_file_features_proto_enumTypes[0].Descriptor()
+}
+
+func (FileEnum) Type() protoreflect.EnumType {
+	return &file_einride_avro_example_v1_example_editions_file_features_proto_enumTypes[0]
+}
+
+func (x FileEnum) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FileEnum.Descriptor instead.
+func (FileEnum) EnumDescriptor() ([]byte, []int) {
+	return file_einride_avro_example_v1_example_editions_file_features_proto_rawDescGZIP(), []int{0}
+}
+
+type OpenFileEnum int32
+
+const (
+	OpenFileEnum_OPEN_FILE_VALUE_UNSPECIFIED OpenFileEnum = 0
+	OpenFileEnum_OPEN_FILE_VALUE1            OpenFileEnum = 1
+)
+
+// Enum value maps for OpenFileEnum.
+var (
+	OpenFileEnum_name = map[int32]string{
+		0: "OPEN_FILE_VALUE_UNSPECIFIED",
+		1: "OPEN_FILE_VALUE1",
+	}
+	OpenFileEnum_value = map[string]int32{
+		"OPEN_FILE_VALUE_UNSPECIFIED": 0,
+		"OPEN_FILE_VALUE1":            1,
+	}
+)
+
+func (x OpenFileEnum) Enum() *OpenFileEnum {
+	p := new(OpenFileEnum)
+	*p = x
+	return p
+}
+
+func (x OpenFileEnum) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OpenFileEnum) Descriptor() protoreflect.EnumDescriptor {
+	return file_einride_avro_example_v1_example_editions_file_features_proto_enumTypes[1].Descriptor()
+}
+
+func (OpenFileEnum) Type() protoreflect.EnumType {
+	return &file_einride_avro_example_v1_example_editions_file_features_proto_enumTypes[1]
+}
+
+func (x OpenFileEnum) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OpenFileEnum.Descriptor instead.
+func (OpenFileEnum) EnumDescriptor() ([]byte, []int) {
+	return file_einride_avro_example_v1_example_editions_file_features_proto_rawDescGZIP(), []int{1}
+}
+
+type ExampleEditionsFileFeatures_NestedEnum int32
+
+const (
+	ExampleEditionsFileFeatures_NESTED_VALUE1 ExampleEditionsFileFeatures_NestedEnum = 1
+)
+
+// Enum value maps for ExampleEditionsFileFeatures_NestedEnum.
+var (
+	ExampleEditionsFileFeatures_NestedEnum_name = map[int32]string{
+		1: "NESTED_VALUE1",
+	}
+	ExampleEditionsFileFeatures_NestedEnum_value = map[string]int32{
+		"NESTED_VALUE1": 1,
+	}
+)
+
+func (x ExampleEditionsFileFeatures_NestedEnum) Enum() *ExampleEditionsFileFeatures_NestedEnum {
+	p := new(ExampleEditionsFileFeatures_NestedEnum)
+	*p = x
+	return p
+}
+
+func (x ExampleEditionsFileFeatures_NestedEnum) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExampleEditionsFileFeatures_NestedEnum) Descriptor() protoreflect.EnumDescriptor {
+	return file_einride_avro_example_v1_example_editions_file_features_proto_enumTypes[2].Descriptor()
+}
+
+func (ExampleEditionsFileFeatures_NestedEnum) Type() protoreflect.EnumType {
+	return &file_einride_avro_example_v1_example_editions_file_features_proto_enumTypes[2]
+}
+
+func (x ExampleEditionsFileFeatures_NestedEnum) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExampleEditionsFileFeatures_NestedEnum.Descriptor instead.
+func (ExampleEditionsFileFeatures_NestedEnum) EnumDescriptor() ([]byte, []int) {
+	return file_einride_avro_example_v1_example_editions_file_features_proto_rawDescGZIP(), []int{0, 0}
+}
+
+type ExampleEditionsFileFeatures struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Enums of the file are closed, unless they set the enum_type feature.
+	FileEnum   *FileEnum                               `protobuf:"varint,1,opt,name=file_enum,json=fileEnum,enum=einride.avro.example.v1.FileEnum" json:"file_enum,omitempty"`
+	NestedEnum *ExampleEditionsFileFeatures_NestedEnum `protobuf:"varint,2,opt,name=nested_enum,json=nestedEnum,enum=einride.avro.example.v1.ExampleEditionsFileFeatures_NestedEnum" json:"nested_enum,omitempty"`
+	OpenEnum   *OpenFileEnum                           `protobuf:"varint,3,opt,name=open_enum,json=openEnum,enum=einride.avro.example.v1.OpenFileEnum" json:"open_enum,omitempty"`
+}
+
+func (x *ExampleEditionsFileFeatures) Reset() {
+	*x = ExampleEditionsFileFeatures{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_einride_avro_example_v1_example_editions_file_features_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExampleEditionsFileFeatures) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExampleEditionsFileFeatures) ProtoMessage() {}
+
+func (x *ExampleEditionsFileFeatures) ProtoReflect() protoreflect.Message {
+	mi := &file_einride_avro_example_v1_example_editions_file_features_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExampleEditionsFileFeatures.ProtoReflect.Descriptor instead.
+func (*ExampleEditionsFileFeatures) Descriptor() ([]byte, []int) {
+	return file_einride_avro_example_v1_example_editions_file_features_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ExampleEditionsFileFeatures) GetFileEnum() FileEnum {
+	if x != nil && x.FileEnum != nil {
+		return *x.FileEnum
+	}
+	return FileEnum_FILE_VALUE1
+}
+
+func (x *ExampleEditionsFileFeatures) GetNestedEnum() ExampleEditionsFileFeatures_NestedEnum {
+	if x != nil && x.NestedEnum != nil {
+		return *x.NestedEnum
+	}
+	return ExampleEditionsFileFeatures_NESTED_VALUE1
+}
+
+func (x *ExampleEditionsFileFeatures) GetOpenEnum() OpenFileEnum {
+	if x != nil && x.OpenEnum != nil {
+		return *x.OpenEnum
+	}
+	return OpenFileEnum_OPEN_FILE_VALUE_UNSPECIFIED
+}
+
+var File_einride_avro_example_v1_example_editions_file_features_proto protoreflect.FileDescriptor
+
+var file_einride_avro_example_v1_example_editions_file_features_proto_rawDesc = []byte{
+	0x0a, 0x3c, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2f, 0x61, 0x76, 0x72, 0x6f, 0x2f, 0x65,
+	0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x5f, 0x65, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x5f,
+	0x66, 0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x17,
+	0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x61, 0x76, 0x72, 0x6f, 0x2e, 0x65, 0x78, 0x61,
+	0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x22, 0xa4, 0x02, 0x0a, 0x1b, 0x45, 0x78, 0x61, 0x6d,
+	0x70, 0x6c, 0x65, 0x45, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x46, 0x69, 0x6c, 0x65, 0x46,
+	0x65, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x12, 0x3e, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f,
+	0x65, 0x6e, 0x75, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x21, 0x2e, 0x65, 0x69, 0x6e,
+	0x72, 0x69, 0x64, 0x65, 0x2e, 0x61, 0x76, 0x72, 0x6f, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x45, 0x6e, 0x75, 0x6d, 0x52, 0x08, 0x66,
+	0x69, 0x6c, 0x65, 0x45, 0x6e, 0x75, 0x6d, 0x12, 0x60, 0x0a, 0x0b, 0x6e, 0x65, 0x73, 0x74, 0x65,
+	0x64, 0x5f, 0x65, 0x6e, 0x75, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x3f, 0x2e, 0x65,
+	0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x61, 0x76, 0x72, 0x6f, 0x2e, 0x65, 0x78, 0x61, 0x6d,
+	0x70, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x45, 0x64,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x46, 0x69, 0x6c, 0x65, 0x46, 0x65, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x73, 0x2e, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x45, 0x6e, 0x75, 0x6d, 0x52, 0x0a, 0x6e,
+	0x65, 0x73, 0x74, 0x65, 0x64, 0x45, 0x6e, 0x75, 0x6d, 0x12, 0x42, 0x0a, 0x09, 0x6f, 0x70, 0x65,
+	0x6e, 0x5f, 0x65, 0x6e, 0x75, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x25, 0x2e, 0x65,
+	0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x61, 0x76, 0x72, 0x6f, 0x2e, 0x65, 0x78, 0x61, 0x6d,
+	0x70, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x65, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x45,
+	0x6e, 0x75, 0x6d, 0x52, 0x08, 0x6f, 0x70, 0x65, 0x6e, 0x45, 0x6e, 0x75, 0x6d, 0x22, 0x1f, 0x0a,
+	0x0a, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x45, 0x6e, 0x75, 0x6d, 0x12, 0x11, 0x0a, 0x0d, 0x4e,
+	0x45, 0x53, 0x54, 0x45, 0x44, 0x5f, 0x56, 0x41, 0x4c, 0x55, 0x45, 0x31, 0x10, 0x01, 0x2a, 0x2c,
+	0x0a, 0x08, 0x46, 0x69, 0x6c, 0x65, 0x45, 0x6e, 0x75, 0x6d, 0x12, 0x0f, 0x0a, 0x0b, 0x46, 0x49,
+	0x4c, 0x45, 0x5f, 0x56, 0x41, 0x4c, 0x55, 0x45, 0x31, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x46,
+	0x49, 0x4c, 0x45, 0x5f, 0x56, 0x41, 0x4c, 0x55, 0x45, 0x32, 0x10, 0x02, 0x2a, 0x4b, 0x0a, 0x0c,
+	0x4f, 0x70, 0x65, 0x6e, 0x46, 0x69, 0x6c, 0x65, 0x45, 0x6e, 0x75, 0x6d, 0x12, 0x1f, 0x0a, 0x1b,
+	0x4f, 0x50, 0x45, 0x4e, 0x5f, 0x46, 0x49, 0x4c, 0x45, 0x5f, 0x56, 0x41, 0x4c, 0x55, 0x45, 0x5f,
+	0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x14, 0x0a,
+	0x10, 0x4f, 0x50, 0x45, 0x4e, 0x5f, 0x46, 0x49, 0x4c, 0x45, 0x5f, 0x56, 0x41, 0x4c, 0x55, 0x45,
+	0x31, 0x10, 0x01, 0x1a, 0x04, 0x3a, 0x02, 0x10, 0x01, 0x42, 0x62, 0x5a, 0x5b, 0x67, 0x6f, 0x2e,
+	0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x74, 0x65, 0x63, 0x68, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2d, 0x61, 0x76, 0x72, 0x6f, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x6e, 0x61, 0x6c, 0x2f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2f, 0x61,
+	0x76, 0x72, 0x6f, 0x2f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2f, 0x76, 0x31, 0x3b, 0x65,
+	0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x76, 0x31, 0x92, 0x03, 0x02, 0x10, 0x02, 0x62, 0x08, 0x65,
+	0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x70, 0xe8, 0x07,
+}
+
+var (
+	file_einride_avro_example_v1_example_editions_file_features_proto_rawDescOnce sync.Once
+	file_einride_avro_example_v1_example_editions_file_features_proto_rawDescData = file_einride_avro_example_v1_example_editions_file_features_proto_rawDesc
+)
+
+func file_einride_avro_example_v1_example_editions_file_features_proto_rawDescGZIP() []byte {
+	file_einride_avro_example_v1_example_editions_file_features_proto_rawDescOnce.Do(func() {
+		file_einride_avro_example_v1_example_editions_file_features_proto_rawDescData = protoimpl.X.CompressGZIP(file_einride_avro_example_v1_example_editions_file_features_proto_rawDescData)
+	})
+	return file_einride_avro_example_v1_example_editions_file_features_proto_rawDescData
+}
+
+var file_einride_avro_example_v1_example_editions_file_features_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_einride_avro_example_v1_example_editions_file_features_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_einride_avro_example_v1_example_editions_file_features_proto_goTypes = []any{
+	(FileEnum)(0),     // 0: einride.avro.example.v1.FileEnum
+	(OpenFileEnum)(0), // 1: einride.avro.example.v1.OpenFileEnum
+	(ExampleEditionsFileFeatures_NestedEnum)(0), // 2: einride.avro.example.v1.ExampleEditionsFileFeatures.NestedEnum
+	(*ExampleEditionsFileFeatures)(nil),         // 3: einride.avro.example.v1.ExampleEditionsFileFeatures
+}
+var file_einride_avro_example_v1_example_editions_file_features_proto_depIdxs = []int32{
+	0, // 0: einride.avro.example.v1.ExampleEditionsFileFeatures.file_enum:type_name -> einride.avro.example.v1.FileEnum
+	2, // 1: einride.avro.example.v1.ExampleEditionsFileFeatures.nested_enum:type_name -> einride.avro.example.v1.ExampleEditionsFileFeatures.NestedEnum
+	1, // 2: einride.avro.example.v1.ExampleEditionsFileFeatures.open_enum:type_name -> einride.avro.example.v1.OpenFileEnum
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_einride_avro_example_v1_example_editions_file_features_proto_init() }
+func file_einride_avro_example_v1_example_editions_file_features_proto_init() {
+	if File_einride_avro_example_v1_example_editions_file_features_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_einride_avro_example_v1_example_editions_file_features_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*ExampleEditionsFileFeatures); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_einride_avro_example_v1_example_editions_file_features_proto_rawDesc,
+			NumEnums:      3,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_einride_avro_example_v1_example_editions_file_features_proto_goTypes,
+		DependencyIndexes: file_einride_avro_example_v1_example_editions_file_features_proto_depIdxs,
+		EnumInfos:         file_einride_avro_example_v1_example_editions_file_features_proto_enumTypes,
+		MessageInfos:      file_einride_avro_example_v1_example_editions_file_features_proto_msgTypes,
+	}.Build()
+	File_einride_avro_example_v1_example_editions_file_features_proto = out.File
+	file_einride_avro_example_v1_example_editions_file_features_proto_rawDesc = nil
+	file_einride_avro_example_v1_example_editions_file_features_proto_goTypes = nil
+	file_einride_avro_example_v1_example_editions_file_features_proto_depIdxs = nil
+}
@@ -0,0 +1,567 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        (unknown)
+// source: einride/avro/example/v1/example_proto2.proto
+
+package examplev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ExampleProto2_Enum int32
+
+const (
+	ExampleProto2_ENUM_VALUE1 ExampleProto2_Enum = 1
+	ExampleProto2_ENUM_VALUE2 ExampleProto2_Enum = 2
+)
+
+// Enum value maps for ExampleProto2_Enum.
+var (
+	ExampleProto2_Enum_name = map[int32]string{
+		1: "ENUM_VALUE1",
+		2: "ENUM_VALUE2",
+	}
+	ExampleProto2_Enum_value = map[string]int32{
+		"ENUM_VALUE1": 1,
+		"ENUM_VALUE2": 2,
+	}
+)
+
+func (x ExampleProto2_Enum) Enum() *ExampleProto2_Enum {
+	p := new(ExampleProto2_Enum)
+	*p = x
+	return p
+}
+
+func (x ExampleProto2_Enum) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExampleProto2_Enum) Descriptor() protoreflect.EnumDescriptor {
+	return file_einride_avro_example_v1_example_proto2_proto_enumTypes[0].Descriptor()
+}
+
+func (ExampleProto2_Enum) Type() protoreflect.EnumType {
+	return &file_einride_avro_example_v1_example_proto2_proto_enumTypes[0]
+}
+
+func (x ExampleProto2_Enum) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Do not use.
+func (x *ExampleProto2_Enum) UnmarshalJSON(b []byte) error {
+	num, err := protoimpl.X.UnmarshalJSONEnum(x.Descriptor(), b)
+	if err != nil {
+		return err
+	}
+	*x = ExampleProto2_Enum(num)
+	return nil
+}
+
+// Deprecated: Use ExampleProto2_Enum.Descriptor instead.
+func (ExampleProto2_Enum) EnumDescriptor() ([]byte, []int) {
+	return file_einride_avro_example_v1_example_proto2_proto_rawDescGZIP(), []int{0, 0}
+}
+
+type ExampleProto2 struct {
+	state           protoimpl.MessageState
+	sizeCache       protoimpl.SizeCache
+	unknownFields   protoimpl.UnknownFields
+	extensionFields protoimpl.ExtensionFields
+
+	RequiredString    *string                      `protobuf:"bytes,1,req,name=required_string,json=requiredString" json:"required_string,omitempty"`
+	OptionalString    *string                      `protobuf:"bytes,2,opt,name=optional_string,json=optionalString" json:"optional_string,omitempty"`
+	DefaultInt32      *int32                       `protobuf:"varint,3,opt,name=default_int32,json=defaultInt32,def=42" json:"default_int32,omitempty"`
+	DefaultString     *string                      `protobuf:"bytes,4,opt,name=default_string,json=defaultString,def=hello" json:"default_string,omitempty"`
+	DefaultEnum       *ExampleProto2_Enum          `protobuf:"varint,5,opt,name=default_enum,json=defaultEnum,enum=einride.avro.example.v1.ExampleProto2_Enum,def=2" json:"default_enum,omitempty"`
+	DefaultBytes      []byte                       `protobuf:"bytes,6,opt,name=default_bytes,json=defaultBytes,def=\\000\\377" json:"default_bytes,omitempty"`
+	DefaultBool       *bool                        `protobuf:"varint,7,opt,name=default_bool,json=defaultBool,def=1" json:"default_bool,omitempty"`
+	DefaultDouble     *float64                     `protobuf:"fixed64,8,opt,name=default_double,json=defaultDouble,def=1.5" json:"default_double,omitempty"`
+	RequiredMessage   *ExampleProto2_Nested        `protobuf:"bytes,9,req,name=required_message,json=requiredMessage" json:"required_message,omitempty"`
+	Optionalgroup     *ExampleProto2_OptionalGroup `protobuf:"group,10,opt,name=OptionalGroup,json=optionalgroup" json:"optionalgroup,omitempty"`
+	RepeatedInt64     []int64                      `protobuf:"varint,12,rep,name=repeated_int64,json=repeatedInt64" json:"repeated_int64,omitempty"`
+	OptionalTimestamp *timestamppb.Timestamp       `protobuf:"bytes,13,opt,name=optional_timestamp,json=optionalTimestamp" json:"optional_timestamp,omitempty"`
+	OptionalEnum      *ExampleProto2_Enum          `protobuf:"varint,14,opt,name=optional_enum,json=optionalEnum,enum=einride.avro.example.v1.ExampleProto2_Enum" json:"optional_enum,omitempty"`
+}
+
+// Default values for ExampleProto2 fields.
+const (
+	Default_ExampleProto2_DefaultInt32  = int32(42)
+	Default_ExampleProto2_DefaultString = string("hello")
+	Default_ExampleProto2_DefaultEnum   = ExampleProto2_ENUM_VALUE2
+	Default_ExampleProto2_DefaultBool   = bool(true)
+	Default_ExampleProto2_DefaultDouble = float64(1.5)
+)
+
+// Default values for ExampleProto2 fields.
+var (
+	Default_ExampleProto2_DefaultBytes = []byte("\x00\xff")
+)
+
+func (x *ExampleProto2) Reset() {
+	*x = ExampleProto2{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_einride_avro_example_v1_example_proto2_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExampleProto2) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExampleProto2) ProtoMessage() {}
+
+func (x *ExampleProto2) ProtoReflect() protoreflect.Message {
+	mi := &file_einride_avro_example_v1_example_proto2_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExampleProto2.ProtoReflect.Descriptor instead.
+func (*ExampleProto2) Descriptor() ([]byte, []int) {
+	return file_einride_avro_example_v1_example_proto2_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ExampleProto2) GetRequiredString() string {
+	if x != nil && x.RequiredString != nil {
+		return *x.RequiredString
+	}
+	return ""
+}
+
+func (x *ExampleProto2) GetOptionalString() string {
+	if x != nil && x.OptionalString != nil {
+		return *x.OptionalString
+	}
+	return ""
+}
+
+func (x *ExampleProto2) GetDefaultInt32() int32 {
+	if x != nil && x.DefaultInt32 != nil {
+		return *x.DefaultInt32
+	}
+	return Default_ExampleProto2_DefaultInt32
+}
+
+func (x *ExampleProto2) GetDefaultString() string {
+	if x != nil && x.DefaultString != nil {
+		return *x.DefaultString
+	}
+	return Default_ExampleProto2_DefaultString
+}
+
+func (x *ExampleProto2) GetDefaultEnum() ExampleProto2_Enum {
+	if x != nil && x.DefaultEnum != nil {
+		return *x.DefaultEnum
+	}
+	return Default_ExampleProto2_DefaultEnum
+}
+
+func (x *ExampleProto2) GetDefaultBytes() []byte {
+	if x != nil && x.DefaultBytes != nil {
+		return x.DefaultBytes
+	}
+	return append([]byte(nil), Default_ExampleProto2_DefaultBytes...)
+}
+
+func (x *ExampleProto2) GetDefaultBool() bool {
+	if x != nil && x.DefaultBool != nil {
+		return *x.DefaultBool
+	}
+	return Default_ExampleProto2_DefaultBool
+}
+
+func (x *ExampleProto2) GetDefaultDouble() float64 {
+	if x != nil && x.DefaultDouble != nil {
+		return *x.DefaultDouble
+	}
+	return Default_ExampleProto2_DefaultDouble
+}
+
+func (x *ExampleProto2) GetRequiredMessage() *ExampleProto2_Nested {
+	if x != nil {
+		return x.RequiredMessage
+	}
+	return nil
+}
+
+func (x *ExampleProto2) GetOptionalgroup() *ExampleProto2_OptionalGroup {
+	if x != nil {
+		return x.Optionalgroup
+	}
+	return nil
+}
+
+func (x *ExampleProto2) GetRepeatedInt64() []int64 {
+	if x != nil {
+		return x.RepeatedInt64
+	}
+	return nil
+}
+
+func (x *ExampleProto2) GetOptionalTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OptionalTimestamp
+	}
+	return nil
+}
+
+func (x *ExampleProto2) GetOptionalEnum() ExampleProto2_Enum {
+	if x != nil && x.OptionalEnum != nil {
+		return *x.OptionalEnum
+	}
+	return ExampleProto2_ENUM_VALUE1
+}
+
+type ExampleProto2_OptionalGroup struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GroupString *string `protobuf:"bytes,11,opt,name=group_string,json=groupString" json:"group_string,omitempty"`
+}
+
+func (x *ExampleProto2_OptionalGroup) Reset() {
+	*x = ExampleProto2_OptionalGroup{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_einride_avro_example_v1_example_proto2_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExampleProto2_OptionalGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExampleProto2_OptionalGroup) ProtoMessage() {}
+
+func (x *ExampleProto2_OptionalGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_einride_avro_example_v1_example_proto2_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExampleProto2_OptionalGroup.ProtoReflect.Descriptor instead.
+func (*ExampleProto2_OptionalGroup) Descriptor() ([]byte, []int) {
+	return file_einride_avro_example_v1_example_proto2_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *ExampleProto2_OptionalGroup) GetGroupString() string {
+	if x != nil && x.GroupString != nil {
+		return *x.GroupString
+	}
+	return ""
+}
+
+type ExampleProto2_Nested struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RequiredInt64 *int64 `protobuf:"varint,1,req,name=required_int64,json=requiredInt64" json:"required_int64,omitempty"`
+}
+
+func (x *ExampleProto2_Nested) Reset() {
+	*x = ExampleProto2_Nested{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_einride_avro_example_v1_example_proto2_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExampleProto2_Nested) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExampleProto2_Nested) ProtoMessage() {}
+
+func (x *ExampleProto2_Nested) ProtoReflect() protoreflect.Message {
+	mi := &file_einride_avro_example_v1_example_proto2_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExampleProto2_Nested.ProtoReflect.Descriptor instead.
+func (*ExampleProto2_Nested) Descriptor() ([]byte, []int) {
+	return file_einride_avro_example_v1_example_proto2_proto_rawDescGZIP(), []int{0, 1}
+}
+
+func (x *ExampleProto2_Nested) GetRequiredInt64() int64 {
+	if x != nil && x.RequiredInt64 != nil {
+		return *x.RequiredInt64
+	}
+	return 0
+}
+
+var file_einride_avro_example_v1_example_proto2_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*ExampleProto2)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         100,
+		Name:          "einride.avro.example.v1.extension_string",
+		Tag:           "bytes,100,opt,name=extension_string",
+		Filename:      "einride/avro/example/v1/example_proto2.proto",
+	},
+	{
+		ExtendedType:  (*ExampleProto2)(nil),
+		ExtensionType: ([]int32)(nil),
+		Field:         101,
+		Name:          "einride.avro.example.v1.extension_int32s",
+		Tag:           "varint,101,rep,name=extension_int32s",
+		Filename:      "einride/avro/example/v1/example_proto2.proto",
+	},
+	{
+		ExtendedType:  (*ExampleProto2)(nil),
+		ExtensionType: (*ExampleProto2_Nested)(nil),
+		Field:         102,
+		Name:          "einride.avro.example.v1.extension_nested",
+		Tag:           "bytes,102,opt,name=extension_nested",
+		Filename:      "einride/avro/example/v1/example_proto2.proto",
+	},
+}
+
+// Extension fields to ExampleProto2.
+var (
+	// optional string extension_string = 100;
+	E_ExtensionString = &file_einride_avro_example_v1_example_proto2_proto_extTypes[0]
+	// repeated int32 extension_int32s = 101;
+	E_ExtensionInt32S = &file_einride_avro_example_v1_example_proto2_proto_extTypes[1]
+	// optional einride.avro.example.v1.ExampleProto2.Nested extension_nested = 102;
+	E_ExtensionNested = &file_einride_avro_example_v1_example_proto2_proto_extTypes[2]
+)
+
+var File_einride_avro_example_v1_example_proto2_proto protoreflect.FileDescriptor
+
+var file_einride_avro_example_v1_example_proto2_proto_rawDesc = []byte{
+	0x0a, 0x2c, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2f, 0x61, 0x76, 0x72, 0x6f, 0x2f, 0x65,
+	0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x5f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x17,
+	0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x61, 0x76, 0x72, 0x6f, 0x2e, 0x65, 0x78, 0x61,
+	0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xa9, 0x07, 0x0a, 0x0d, 0x45, 0x78, 0x61,
+	0x6d, 0x70, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0x12, 0x27, 0x0a, 0x0f, 0x72, 0x65,
+	0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x5f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20,
+	0x02, 0x28, 0x09, 0x52, 0x0e, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x53, 0x74, 0x72,
+	0x69, 0x6e, 0x67, 0x12, 0x27, 0x0a, 0x0f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f,
+	0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x6f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x27, 0x0a, 0x0d,
+	0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x69, 0x6e, 0x74, 0x33, 0x32, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x3a, 0x02, 0x34, 0x32, 0x52, 0x0c, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74,
+	0x49, 0x6e, 0x74, 0x33, 0x32, 0x12, 0x2c, 0x0a, 0x0e, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74,
+	0x5f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x3a, 0x05, 0x68,
+	0x65, 0x6c, 0x6c, 0x6f, 0x52, 0x0d, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x53, 0x74, 0x72,
+	0x69, 0x6e, 0x67, 0x12, 0x5b, 0x0a, 0x0c, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x65,
+	0x6e, 0x75, 0x6d, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2b, 0x2e, 0x65, 0x69, 0x6e, 0x72,
+	0x69, 0x64, 0x65, 0x2e, 0x61, 0x76, 0x72, 0x6f, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f,
+	0x32, 0x2e, 0x45, 0x6e, 0x75, 0x6d, 0x3a, 0x0b, 0x45, 0x4e, 0x55, 0x4d, 0x5f, 0x56, 0x41, 0x4c,
+	0x55, 0x45, 0x32, 0x52, 0x0b, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x45, 0x6e, 0x75, 0x6d,
+	0x12, 0x2d, 0x0a, 0x0d, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x62, 0x79, 0x74, 0x65,
+	0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x3a, 0x08, 0x5c, 0x30, 0x30, 0x30, 0x5c, 0x33, 0x37,
+	0x37, 0x52, 0x0c, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12,
+	0x27, 0x0a, 0x0c, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x62, 0x6f, 0x6f, 0x6c, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x08, 0x3a, 0x04, 0x74, 0x72, 0x75, 0x65, 0x52, 0x0b, 0x64, 0x65, 0x66,
+	0x61, 0x75, 0x6c, 0x74, 0x42, 0x6f, 0x6f, 0x6c, 0x12, 0x2a, 0x0a, 0x0e, 0x64, 0x65, 0x66, 0x61,
+	0x75, 0x6c, 0x74, 0x5f, 0x64, 0x6f, 0x75, 0x62, 0x6c, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01,
+	0x3a, 0x03, 0x31, 0x2e, 0x35, 0x52, 0x0d, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x44, 0x6f,
+	0x75, 0x62, 0x6c, 0x65, 0x12, 0x58, 0x0a, 0x10, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64,
+	0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x09, 0x20, 0x02, 0x28, 0x0b, 0x32, 0x2d,
+	0x2e, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x61, 0x76, 0x72, 0x6f, 0x2e, 0x65, 0x78,
+	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65,
+	0x50, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0x2e, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x52, 0x0f, 0x72,
+	0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x5a,
+	0x0a, 0x0d, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x0a, 0x32, 0x34, 0x2e, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2e,
+	0x61, 0x76, 0x72, 0x6f, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0x2e, 0x4f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x0d, 0x6f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65,
+	0x70, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x69, 0x6e, 0x74, 0x36, 0x34, 0x18, 0x0c, 0x20, 0x03,
+	0x28, 0x03, 0x52, 0x0d, 0x72, 0x65, 0x70, 0x65, 0x61, 0x74, 0x65, 0x64, 0x49, 0x6e, 0x74, 0x36,
+	0x34, 0x12, 0x49, 0x0a, 0x12, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x11, 0x6f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x61, 0x6c, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x50, 0x0a, 0x0d,
+	0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f, 0x65, 0x6e, 0x75, 0x6d, 0x18, 0x0e, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x2b, 0x2e, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x61, 0x76,
+	0x72, 0x6f, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78,
+	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0x2e, 0x45, 0x6e, 0x75, 0x6d,
+	0x52, 0x0c, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x45, 0x6e, 0x75, 0x6d, 0x1a, 0x32,
+	0x0a, 0x0d, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12,
+	0x21, 0x0a, 0x0c, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x53, 0x74, 0x72, 0x69,
+	0x6e, 0x67, 0x1a, 0x2f, 0x0a, 0x06, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x12, 0x25, 0x0a, 0x0e,
+	0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x5f, 0x69, 0x6e, 0x74, 0x36, 0x34, 0x18, 0x01,
+	0x20, 0x02, 0x28, 0x03, 0x52, 0x0d, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x49, 0x6e,
+	0x74, 0x36, 0x34, 0x22, 0x28, 0x0a, 0x04, 0x45, 0x6e, 0x75, 0x6d, 0x12, 0x0f, 0x0a, 0x0b, 0x45,
+	0x4e, 0x55, 0x4d, 0x5f, 0x56, 0x41, 0x4c, 0x55, 0x45, 0x31, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b,
+	0x45, 0x4e, 0x55, 0x4d, 0x5f, 0x56, 0x41, 0x4c, 0x55, 0x45, 0x32, 0x10, 0x02, 0x2a, 0x05, 0x08,
+	0x64, 0x10, 0xc8, 0x01, 0x3a, 0x51, 0x0a, 0x10, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f,
+	0x6e, 0x5f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x26, 0x2e, 0x65, 0x69, 0x6e, 0x72, 0x69,
+	0x64, 0x65, 0x2e, 0x61, 0x76, 0x72, 0x6f, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x32,
+	0x18, 0x64, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f,
+	0x6e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x3a, 0x51, 0x0a, 0x10, 0x65, 0x78, 0x74, 0x65, 0x6e,
+	0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x6e, 0x74, 0x33, 0x32, 0x73, 0x12, 0x26, 0x2e, 0x65, 0x69,
+	0x6e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x61, 0x76, 0x72, 0x6f, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70,
+	0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x50, 0x72, 0x6f,
+	0x74, 0x6f, 0x32, 0x18, 0x65, 0x20, 0x03, 0x28, 0x05, 0x52, 0x0f, 0x65, 0x78, 0x74, 0x65, 0x6e,
+	0x73, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x74, 0x33, 0x32, 0x73, 0x3a, 0x80, 0x01, 0x0a, 0x10, 0x65,
+	0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x12,
+	0x26, 0x2e, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x61, 0x76, 0x72, 0x6f, 0x2e, 0x65,
+	0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0x18, 0x66, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e,
+	0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x61, 0x76, 0x72, 0x6f, 0x2e, 0x65, 0x78, 0x61,
+	0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x50,
+	0x72, 0x6f, 0x74, 0x6f, 0x32, 0x2e, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x52, 0x0f, 0x65, 0x78,
+	0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x42, 0x5d, 0x5a,
+	0x5b, 0x67, 0x6f, 0x2e, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x74, 0x65, 0x63, 0x68,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2d, 0x61, 0x76, 0x72, 0x6f, 0x2f, 0x69,
+	0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x65, 0x69, 0x6e, 0x72, 0x69,
+	0x64, 0x65, 0x2f, 0x61, 0x76, 0x72, 0x6f, 0x2f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2f,
+	0x76, 0x31, 0x3b, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x76, 0x31,
+}
+
+var (
+	file_einride_avro_example_v1_example_proto2_proto_rawDescOnce sync.Once
+	file_einride_avro_example_v1_example_proto2_proto_rawDescData = file_einride_avro_example_v1_example_proto2_proto_rawDesc
+)
+
+func file_einride_avro_example_v1_example_proto2_proto_rawDescGZIP() []byte {
+	file_einride_avro_example_v1_example_proto2_proto_rawDescOnce.Do(func() {
+		file_einride_avro_example_v1_example_proto2_proto_rawDescData = protoimpl.X.CompressGZIP(file_einride_avro_example_v1_example_proto2_proto_rawDescData)
+	})
+	return file_einride_avro_example_v1_example_proto2_proto_rawDescData
+}
+
+var file_einride_avro_example_v1_example_proto2_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_einride_avro_example_v1_example_proto2_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_einride_avro_example_v1_example_proto2_proto_goTypes = []interface{}{
+	(ExampleProto2_Enum)(0),             // 0: einride.avro.example.v1.ExampleProto2.Enum
+	(*ExampleProto2)(nil),               // 1: einride.avro.example.v1.ExampleProto2
+	(*ExampleProto2_OptionalGroup)(nil), // 2: einride.avro.example.v1.ExampleProto2.OptionalGroup
+	(*ExampleProto2_Nested)(nil),        // 3: einride.avro.example.v1.ExampleProto2.Nested
+	(*timestamppb.Timestamp)(nil),       // 4: google.protobuf.Timestamp
+}
+var file_einride_avro_example_v1_example_proto2_proto_depIdxs = []int32{
+	0, // 0: einride.avro.example.v1.ExampleProto2.default_enum:type_name -> einride.avro.example.v1.ExampleProto2.Enum
+	3, // 1: einride.avro.example.v1.ExampleProto2.required_message:type_name -> einride.avro.example.v1.ExampleProto2.Nested
+	2, // 2: einride.avro.example.v1.ExampleProto2.optionalgroup:type_name -> einride.avro.example.v1.ExampleProto2.OptionalGroup
+	4, // 3: einride.avro.example.v1.ExampleProto2.optional_timestamp:type_name -> google.protobuf.Timestamp
+	0, // 4: einride.avro.example.v1.ExampleProto2.optional_enum:type_name -> einride.avro.example.v1.ExampleProto2.Enum
+	1, // 5: einride.avro.example.v1.extension_string:extendee -> einride.avro.example.v1.ExampleProto2
+	1, // 6: einride.avro.example.v1.extension_int32s:extendee -> einride.avro.example.v1.ExampleProto2
+	1, // 7: einride.avro.example.v1.extension_nested:extendee -> einride.avro.example.v1.ExampleProto2
+	3, // 8: einride.avro.example.v1.extension_nested:type_name -> einride.avro.example.v1.ExampleProto2.Nested
+	9, // [9:9] is the sub-list for method output_type
+	9, // [9:9] is the sub-list for method input_type
+	8, // [8:9] is the sub-list for extension type_name
+	5, // [5:8] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_einride_avro_example_v1_example_proto2_proto_init() }
+func file_einride_avro_example_v1_example_proto2_proto_init() {
+	if File_einride_avro_example_v1_example_proto2_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_einride_avro_example_v1_example_proto2_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExampleProto2); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			case 3:
+				return &v.extensionFields
+			default:
+				return nil
+			}
+		}
+		file_einride_avro_example_v1_example_proto2_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExampleProto2_OptionalGroup); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_einride_avro_example_v1_example_proto2_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExampleProto2_Nested); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_einride_avro_example_v1_example_proto2_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   3,
+			NumExtensions: 3,
+			NumServices:   0,
+		},
+		GoTypes:           file_einride_avro_example_v1_example_proto2_proto_goTypes,
+		DependencyIndexes: file_einride_avro_example_v1_example_proto2_proto_depIdxs,
+		EnumInfos:         file_einride_avro_example_v1_example_proto2_proto_enumTypes,
+		MessageInfos:      file_einride_avro_example_v1_example_proto2_proto_msgTypes,
+		ExtensionInfos:    file_einride_avro_example_v1_example_proto2_proto_extTypes,
+	}.Build()
+	File_einride_avro_example_v1_example_proto2_proto = out.File
+	file_einride_avro_example_v1_example_proto2_proto_rawDesc = nil
+	file_einride_avro_example_v1_example_proto2_proto_goTypes = nil
+	file_einride_avro_example_v1_example_proto2_proto_depIdxs = nil
+}
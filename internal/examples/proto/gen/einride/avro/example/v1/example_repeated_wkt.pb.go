@@ -0,0 +1,237 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.31.0
+// 	protoc        (unknown)
+// source: einride/avro/example/v1/example_repeated_wkt.proto
+
+package examplev1
+
+import (
+	date "google.golang.org/genproto/googleapis/type/date"
+	datetime "google.golang.org/genproto/googleapis/type/datetime"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	anypb "google.golang.org/protobuf/types/known/anypb"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ExampleRepeatedWKT struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Timestamps []*timestamppb.Timestamp `protobuf:"bytes,1,rep,name=timestamps,proto3" json:"timestamps,omitempty"`
+	Durations  []*durationpb.Duration   `protobuf:"bytes,2,rep,name=durations,proto3" json:"durations,omitempty"`
+	Dates      []*date.Date             `protobuf:"bytes,3,rep,name=dates,proto3" json:"dates,omitempty"`
+	DateTimes  []*datetime.DateTime     `protobuf:"bytes,4,rep,name=date_times,json=dateTimes,proto3" json:"date_times,omitempty"`
+	Structs    []*structpb.Struct       `protobuf:"bytes,5,rep,name=structs,proto3" json:"structs,omitempty"`
+	Anys       []*anypb.Any             `protobuf:"bytes,6,rep,name=anys,proto3" json:"anys,omitempty"`
+}
+
+func (x *ExampleRepeatedWKT) Reset() {
+	*x = ExampleRepeatedWKT{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_einride_avro_example_v1_example_repeated_wkt_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExampleRepeatedWKT) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExampleRepeatedWKT) ProtoMessage() {}
+
+func (x *ExampleRepeatedWKT) ProtoReflect() protoreflect.Message {
+	mi := &file_einride_avro_example_v1_example_repeated_wkt_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExampleRepeatedWKT.ProtoReflect.Descriptor instead.
+func (*ExampleRepeatedWKT) Descriptor() ([]byte, []int) {
+	return file_einride_avro_example_v1_example_repeated_wkt_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ExampleRepeatedWKT) GetTimestamps() []*timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamps
+	}
+	return nil
+}
+
+func (x *ExampleRepeatedWKT) GetDurations() []*durationpb.Duration {
+	if x != nil {
+		return x.Durations
+	}
+	return nil
+}
+
+func (x *ExampleRepeatedWKT) GetDates() []*date.Date {
+	if x != nil {
+		return x.Dates
+	}
+	return nil
+}
+
+func (x *ExampleRepeatedWKT) GetDateTimes() []*datetime.DateTime {
+	if x != nil {
+		return x.DateTimes
+	}
+	return nil
+}
+
+func (x *ExampleRepeatedWKT) GetStructs() []*structpb.Struct {
+	if x != nil {
+		return x.Structs
+	}
+	return nil
+}
+
+func (x *ExampleRepeatedWKT) GetAnys() []*anypb.Any {
+	if x != nil {
+		return x.Anys
+	}
+	return nil
+}
+
+var File_einride_avro_example_v1_example_repeated_wkt_proto protoreflect.FileDescriptor
+
+var file_einride_avro_example_v1_example_repeated_wkt_proto_rawDesc = []byte{
+	0x0a, 0x32, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2f, 0x61, 0x76, 0x72, 0x6f, 0x2f, 0x65,
+	0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x5f, 0x72, 0x65, 0x70, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x77, 0x6b, 0x74, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x17, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x61, 0x76,
+	0x72, 0x6f, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x1a, 0x19, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x61,
+	0x6e, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x16, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
+	0x74, 0x79, 0x70, 0x65, 0x2f, 0x64, 0x61, 0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
+	0x1a, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x2f, 0x64, 0x61, 0x74,
+	0x65, 0x74, 0x69, 0x6d, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xc5, 0x02, 0x0a, 0x12,
+	0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x52, 0x65, 0x70, 0x65, 0x61, 0x74, 0x65, 0x64, 0x57,
+	0x4b, 0x54, 0x12, 0x3a, 0x0a, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x73, 0x12, 0x37,
+	0x0a, 0x09, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x64, 0x75,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x27, 0x0a, 0x05, 0x64, 0x61, 0x74, 0x65, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x74, 0x79, 0x70, 0x65, 0x2e, 0x44, 0x61, 0x74, 0x65, 0x52, 0x05, 0x64, 0x61, 0x74, 0x65, 0x73,
+	0x12, 0x34, 0x0a, 0x0a, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x74, 0x79,
+	0x70, 0x65, 0x2e, 0x44, 0x61, 0x74, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x52, 0x09, 0x64, 0x61, 0x74,
+	0x65, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x12, 0x31, 0x0a, 0x07, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74,
+	0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74,
+	0x52, 0x07, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x73, 0x12, 0x28, 0x0a, 0x04, 0x61, 0x6e, 0x79,
+	0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x04, 0x61,
+	0x6e, 0x79, 0x73, 0x42, 0x5d, 0x5a, 0x5b, 0x67, 0x6f, 0x2e, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64,
+	0x65, 0x2e, 0x74, 0x65, 0x63, 0x68, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2d,
+	0x61, 0x76, 0x72, 0x6f, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x65, 0x78,
+	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x65, 0x6e,
+	0x2f, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2f, 0x61, 0x76, 0x72, 0x6f, 0x2f, 0x65, 0x78,
+	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2f, 0x76, 0x31, 0x3b, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65,
+	0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_einride_avro_example_v1_example_repeated_wkt_proto_rawDescOnce sync.Once
+	file_einride_avro_example_v1_example_repeated_wkt_proto_rawDescData = file_einride_avro_example_v1_example_repeated_wkt_proto_rawDesc
+)
+
+func file_einride_avro_example_v1_example_repeated_wkt_proto_rawDescGZIP() []byte {
+	file_einride_avro_example_v1_example_repeated_wkt_proto_rawDescOnce.Do(func() {
+		file_einride_avro_example_v1_example_repeated_wkt_proto_rawDescData = protoimpl.X.CompressGZIP(file_einride_avro_example_v1_example_repeated_wkt_proto_rawDescData)
+	})
+	return file_einride_avro_example_v1_example_repeated_wkt_proto_rawDescData
+}
+
+var file_einride_avro_example_v1_example_repeated_wkt_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_einride_avro_example_v1_example_repeated_wkt_proto_goTypes = []interface{}{
+	(*ExampleRepeatedWKT)(nil),    // 0: einride.avro.example.v1.ExampleRepeatedWKT
+	(*timestamppb.Timestamp)(nil), // 1: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),   // 2: google.protobuf.Duration
+	(*date.Date)(nil),             // 3: google.type.Date
+	(*datetime.DateTime)(nil),     // 4: google.type.DateTime
+	(*structpb.Struct)(nil),       // 5: google.protobuf.Struct
+	(*anypb.Any)(nil),             // 6: google.protobuf.Any
+}
+var file_einride_avro_example_v1_example_repeated_wkt_proto_depIdxs = []int32{
+	1, // 0: einride.avro.example.v1.ExampleRepeatedWKT.timestamps:type_name -> google.protobuf.Timestamp
+	2, // 1: einride.avro.example.v1.ExampleRepeatedWKT.durations:type_name -> google.protobuf.Duration
+	3, // 2: einride.avro.example.v1.ExampleRepeatedWKT.dates:type_name -> google.type.Date
+	4, // 3: einride.avro.example.v1.ExampleRepeatedWKT.date_times:type_name -> google.type.DateTime
+	5, // 4: einride.avro.example.v1.ExampleRepeatedWKT.structs:type_name -> google.protobuf.Struct
+	6, // 5: einride.avro.example.v1.ExampleRepeatedWKT.anys:type_name -> google.protobuf.Any
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_einride_avro_example_v1_example_repeated_wkt_proto_init() }
+func file_einride_avro_example_v1_example_repeated_wkt_proto_init() {
+	if File_einride_avro_example_v1_example_repeated_wkt_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_einride_avro_example_v1_example_repeated_wkt_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExampleRepeatedWKT); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_einride_avro_example_v1_example_repeated_wkt_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_einride_avro_example_v1_example_repeated_wkt_proto_goTypes,
+		DependencyIndexes: file_einride_avro_example_v1_example_repeated_wkt_proto_depIdxs,
+		MessageInfos:      file_einride_avro_example_v1_example_repeated_wkt_proto_msgTypes,
+	}.Build()
+	File_einride_avro_example_v1_example_repeated_wkt_proto = out.File
+	file_einride_avro_example_v1_example_repeated_wkt_proto_rawDesc = nil
+	file_einride_avro_example_v1_example_repeated_wkt_proto_goTypes = nil
+	file_einride_avro_example_v1_example_repeated_wkt_proto_depIdxs = nil
+}